@@ -27,6 +27,25 @@ func BenchmarkScope_SpawnWait(b *testing.B) {
 	}
 }
 
+func BenchmarkScope_SpawnWaitPool(b *testing.B) {
+	pool := scope.NewPoolExecutor(128, 0)
+	defer pool.Close()
+	for _, n := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("tasks_%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				s := scope.New(context.Background(), scope.FailFast, scope.WithExecutor(pool))
+				for j := 0; j < n; j++ {
+					s.Go(func(context.Context) error { return nil })
+				}
+				if err := s.Wait(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkErrgroup_SpawnWait(b *testing.B) {
 	for _, n := range []int{1, 10, 100} {
 		b.Run(fmt.Sprintf("tasks_%d", n), func(b *testing.B) {
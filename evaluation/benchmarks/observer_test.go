@@ -18,21 +18,21 @@ import (
 
 type nopObs struct{}
 
-func (nopObs) ScopeCreated(context.Context)                           {}
-func (nopObs) ScopeCancelled(context.Context, error)                  {}
-func (nopObs) ScopeJoined(context.Context, time.Duration)             {}
-func (nopObs) TaskStarted(context.Context)                            {}
-func (nopObs) TaskFinished(context.Context, time.Duration, error, bool) {}
+func (nopObs) ScopeCreated(context.Context)                              {}
+func (nopObs) ScopeCancelled(context.Context, scope.CancelReason, error) {}
+func (nopObs) ScopeJoined(context.Context, time.Duration)                {}
+func (nopObs) TaskStarted(context.Context)                               {}
+func (nopObs) TaskFinished(context.Context, time.Duration, error, bool)  {}
 
 type countingObs struct {
 	started  atomic.Int64
 	finished atomic.Int64
 }
 
-func (o *countingObs) ScopeCreated(context.Context)               {}
-func (o *countingObs) ScopeCancelled(context.Context, error)      {}
-func (o *countingObs) ScopeJoined(context.Context, time.Duration) {}
-func (o *countingObs) TaskStarted(context.Context)                { o.started.Add(1) }
+func (o *countingObs) ScopeCreated(context.Context)                              {}
+func (o *countingObs) ScopeCancelled(context.Context, scope.CancelReason, error) {}
+func (o *countingObs) ScopeJoined(context.Context, time.Duration)                {}
+func (o *countingObs) TaskStarted(context.Context)                               { o.started.Add(1) }
 func (o *countingObs) TaskFinished(_ context.Context, _ time.Duration, _ error, _ bool) {
 	o.finished.Add(1)
 }
@@ -95,7 +95,7 @@ func TestLostErr_Errgroup_OnlyFirstCaptured(t *testing.T) {
 }
 
 // TestLostErr_Scope_SupervisorAggregatesAll shows that scope's Supervisor
-// policy collects every error via errors.Join.
+// policy collects every error in a *scope.ScopeError.
 func TestLostErr_Scope_SupervisorAggregatesAll(t *testing.T) {
 	t.Parallel()
 	s := scope.New(context.Background(), scope.Supervisor)
@@ -60,5 +60,5 @@ func SupervisorScope(ctx context.Context, tasks []func(context.Context) error) e
 	for _, fn := range tasks {
 		s.Go(fn)
 	}
-	return s.Wait() // errors.Join of all errors
+	return s.Wait() // *scope.ScopeError with every task failure
 }
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	scopehttp "github.com/NetPo4ki/go-scope/interop/http"
 	"github.com/NetPo4ki/go-scope/scope"
 )
 
@@ -52,13 +53,12 @@ func render(w http.ResponseWriter, p Profile, hist []Event, recs []Item) {
 }
 
 // GetPage demonstrates:
-// - request-scoped timeout (200ms)
+// - request scope created by the scopehttp middleware (fail-fast, 200ms timeout)
 // - fail-fast policy for critical subrequests (profile, history)
 // - supervisor child scope for recommendations
 // - bounded parallelism on the child scope via WithMaxConcurrency
 func GetPage(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	s := scope.New(ctx, scope.FailFast, scope.WithTimeout(200*time.Millisecond))
+	s := scopehttp.FromRequest(r)
 
 	var prof Profile
 	s.Go(func(ctx context.Context) error {
@@ -109,6 +109,7 @@ func GetPage(w http.ResponseWriter, r *http.Request) {
 
 func main() {
 	// Minimal HTTP server to demo the flow.
-	http.HandleFunc("/page", GetPage)
+	mw := scopehttp.New(scope.FailFast, scopehttp.WithScopeOptions(scope.WithTimeout(200*time.Millisecond)))
+	http.Handle("/page", mw.Wrap(http.HandlerFunc(GetPage)))
 	_ = http.ListenAndServe(":8080", nil)
 }
@@ -19,8 +19,8 @@ type counterObserver struct {
 }
 
 func (o *counterObserver) ScopeCreated(_ context.Context) {}
-func (o *counterObserver) ScopeCancelled(_ context.Context, cause error) {
-	fmt.Println("cancel cause:", cause)
+func (o *counterObserver) ScopeCancelled(_ context.Context, reason scope.CancelReason, cause error) {
+	fmt.Println("cancel reason:", reason, "cause:", cause)
 }
 func (o *counterObserver) ScopeJoined(_ context.Context, wait time.Duration) {
 	fmt.Println("join latency:", wait)
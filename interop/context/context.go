@@ -21,6 +21,12 @@ func ScopeContext(s *scope.Scope) stdctx.Context {
 	return s.Context()
 }
 
+// FromContext is [scope.FromContext], kept here next to its inverse
+// ScopeContext.
+func FromContext(ctx stdctx.Context) (scope.Spawner, bool) {
+	return scope.FromContext(ctx)
+}
+
 // LinkedContext returns a child of parent that is also canceled when link is
 // done. If parent is nil, [stdctx.Background] is used. If link is nil, the
 // result is [stdctx.WithCancel](parent) with no extra linkage.
@@ -23,6 +23,18 @@ func TestScopeContextMatchesScope(t *testing.T) {
 	}
 }
 
+func TestFromContextRoundTrip(t *testing.T) {
+	t.Parallel()
+	if sp, ok := FromContext(stdctx.Background()); ok || sp != nil {
+		t.Fatal("expected nil scope for plain context")
+	}
+	s := scope.New(stdctx.Background(), scope.FailFast)
+	if sp, ok := FromContext(ScopeContext(s)); !ok || sp.Context() != s.Context() {
+		t.Fatal("FromContext should return the scope owning the context")
+	}
+	_ = s.Wait()
+}
+
 func TestLinkedContextNilLink(t *testing.T) {
 	t.Parallel()
 	parent, stop := stdctx.WithCancel(stdctx.Background())
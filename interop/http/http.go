@@ -0,0 +1,243 @@
+// Package http provides net/http middleware that runs every request inside
+// its own [scope.Scope]. Import as a named package to avoid clashing with the
+// standard net/http package, for example:
+//
+//	import scopehttp "github.com/NetPo4ki/go-scope/interop/http"
+//
+// The request scope is derived from r.Context() and stored in the request
+// context, so handlers obtain it with [FromRequest], or its Spawner view with
+// [scope.FromContext].
+// Once the handler returns, the middleware joins the scope, including any
+// background tasks the handler did not wait for, and maps a scope error to an
+// HTTP status if the handler has not written a response. The Middleware also
+// tracks in-flight request scopes so that Shutdown can drain them alongside
+// [net/http.Server.Shutdown]:
+//
+//	mw := scopehttp.New(scope.FailFast, scopehttp.WithScopeOptions(scope.WithTimeout(time.Second)))
+//	srv := &http.Server{Handler: mw.Wrap(mux)}
+//	...
+//	_ = srv.Shutdown(ctx)
+//	_ = mw.Shutdown(ctx)
+package http
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/NetPo4ki/go-scope/scope"
+)
+
+// ErrHandlerPanicked is the cancellation cause of a request scope whose
+// handler panicked.
+var ErrHandlerPanicked = errors.New("scopehttp: handler panicked")
+
+// Option configures a Middleware.
+type Option func(*Middleware)
+
+// WithScopeOptions sets the options applied to every request scope, for
+// example scope.WithTimeout or scope.WithMaxConcurrency.
+func WithScopeOptions(optFns ...scope.Option) Option {
+	return func(m *Middleware) { m.opts = append(m.opts, optFns...) }
+}
+
+// WithStatusFunc replaces StatusFor as the mapping from a scope error to the
+// HTTP status written when the handler has not responded. A nil fn is
+// ignored.
+func WithStatusFunc(fn func(error) int) Option {
+	return func(m *Middleware) {
+		if fn != nil {
+			m.status = fn
+		}
+	}
+}
+
+// Middleware creates, joins and tracks request scopes.
+type Middleware struct {
+	policy scope.Policy
+	opts   []scope.Option
+	status func(error) int
+
+	mu       sync.Mutex
+	inflight map[*scope.Scope]struct{}
+	draining bool
+	drained  chan struct{}
+}
+
+// New creates a Middleware whose request scopes use policy.
+func New(policy scope.Policy, optFns ...Option) *Middleware {
+	m := &Middleware{
+		policy:   policy,
+		status:   StatusFor,
+		inflight: make(map[*scope.Scope]struct{}),
+	}
+	for _, fn := range optFns {
+		if fn != nil {
+			fn(m)
+		}
+	}
+	return m
+}
+
+type requestKey struct{}
+
+// FromRequest returns the request scope created by the middleware, or nil if
+// the request did not pass through it. The handler may Wait on it; the tasks
+// of the scope only reach it as a Spawner through scope.FromContext.
+func FromRequest(r *http.Request) *scope.Scope {
+	s, _ := r.Context().Value(requestKey{}).(*scope.Scope)
+	return s
+}
+
+// StatusFor is the default mapping from a scope error to an HTTP status:
+// deadlines map to 504, cancellation and refused admission to 503, and any
+// other error, including panics, to 500.
+func StatusFor(err error) int {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, scope.ErrScopeCanceled),
+		errors.Is(err, scope.ErrScopeClosed),
+		errors.Is(err, scope.ErrNotAdmitted):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Wrap returns a handler that runs next inside a request scope. While the
+// Middleware is shutting down, new requests are refused with 503.
+func (m *Middleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s, ok := m.begin(r.Context())
+		if !ok {
+			code := http.StatusServiceUnavailable
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+		// Registered first so it also runs when Wait re-panics under
+		// scope.WithRepanic.
+		defer m.end(s)
+		completed := false
+		defer func() {
+			if !completed {
+				s.Cancel(ErrHandlerPanicked)
+				_ = s.Wait()
+			}
+		}()
+
+		rw := &responseWriter{ResponseWriter: w}
+		next.ServeHTTP(rw, r.WithContext(context.WithValue(s.Context(), requestKey{}, s)))
+		completed = true
+		if err := s.Wait(); err != nil && !rw.wroteHeader {
+			code := m.status(err)
+			http.Error(rw, http.StatusText(code), code)
+		}
+	})
+}
+
+// InFlight reports the number of request scopes that have not been joined.
+func (m *Middleware) InFlight() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.inflight)
+}
+
+// Shutdown stops admitting requests and waits until every in-flight request
+// scope has been joined. If ctx is done first, the remaining scopes are
+// canceled with scope.Shutdown and ctx.Err() is returned without waiting for
+// them further.
+func (m *Middleware) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.draining = true
+	if len(m.inflight) == 0 {
+		m.mu.Unlock()
+		return nil
+	}
+	if m.drained == nil {
+		m.drained = make(chan struct{})
+	}
+	drained := m.drained
+	m.mu.Unlock()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+	}
+	m.mu.Lock()
+	for s := range m.inflight {
+		s.Shutdown(context.Cause(ctx))
+	}
+	m.mu.Unlock()
+	return ctx.Err()
+}
+
+func (m *Middleware) begin(parent context.Context) (*scope.Scope, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.draining {
+		return nil, false
+	}
+	s := scope.New(parent, m.policy, m.opts...)
+	m.inflight[s] = struct{}{}
+	return s, true
+}
+
+func (m *Middleware) end(s *scope.Scope) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.inflight, s)
+	if len(m.inflight) == 0 && m.drained != nil {
+		close(m.drained)
+		m.drained = nil
+	}
+}
+
+// responseWriter records whether the handler has started a response.
+type responseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher when the underlying writer supports it.
+func (w *responseWriter) Flush() {
+	if http.NewResponseController(w.ResponseWriter).Flush() == nil {
+		w.wroteHeader = true
+	}
+}
+
+// Hijack implements http.Hijacker when the underlying writer supports it.
+// The scope's status is not written to a hijacked connection.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, buf, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err == nil {
+		w.wroteHeader = true
+	}
+	return conn, buf, err
+}
+
+// Push implements http.Pusher when the underlying writer supports it.
+func (w *responseWriter) Push(target string, opts *http.PushOptions) error {
+	if p, ok := w.ResponseWriter.(http.Pusher); ok {
+		return p.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *responseWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
@@ -0,0 +1,224 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NetPo4ki/go-scope/scope"
+)
+
+func serve(m *Middleware, h http.HandlerFunc) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	m.Wrap(h).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	return rec
+}
+
+func TestWrapJoinsBackgroundTasks(t *testing.T) {
+	t.Parallel()
+	m := New(scope.FailFast)
+	var finished atomic.Bool
+	rec := serve(m, func(w http.ResponseWriter, r *http.Request) {
+		s := FromRequest(r)
+		if s == nil {
+			t.Error("no request scope")
+			return
+		}
+		s.Go(func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			finished.Store(true)
+			return nil
+		})
+		w.WriteHeader(http.StatusAccepted)
+	})
+	if !finished.Load() {
+		t.Fatal("background task not joined before the middleware returned")
+	}
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want 202", rec.Code)
+	}
+	if n := m.InFlight(); n != 0 {
+		t.Fatalf("InFlight() = %d, want 0", n)
+	}
+}
+
+func TestWrapMapsScopeErrors(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name string
+		opts []scope.Option
+		task func(ctx context.Context) error
+		want int
+	}{
+		{"error", nil, func(context.Context) error { return errors.New("boom") }, http.StatusInternalServerError},
+		{"panic", []scope.Option{scope.WithPanicAsError(true)}, func(context.Context) error { panic("boom") }, http.StatusInternalServerError},
+		{"deadline", []scope.Option{scope.WithTimeout(5 * time.Millisecond)}, func(ctx context.Context) error {
+			<-ctx.Done()
+			return context.Cause(ctx)
+		}, http.StatusGatewayTimeout},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			m := New(scope.FailFast, WithScopeOptions(tc.opts...))
+			rec := serve(m, func(w http.ResponseWriter, r *http.Request) {
+				FromRequest(r).Go(tc.task)
+			})
+			if rec.Code != tc.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.want)
+			}
+		})
+	}
+}
+
+func TestWrapKeepsWrittenResponse(t *testing.T) {
+	t.Parallel()
+	m := New(scope.FailFast, WithStatusFunc(func(error) int { return http.StatusTeapot }))
+	rec := serve(m, func(w http.ResponseWriter, r *http.Request) {
+		FromRequest(r).Go(func(context.Context) error { return errors.New("late") })
+		_, _ = w.Write([]byte("ok"))
+	})
+	if rec.Code != http.StatusOK || rec.Body.String() != "ok" {
+		t.Fatalf("response = %d %q, want 200 ok", rec.Code, rec.Body.String())
+	}
+
+	rec = serve(m, func(w http.ResponseWriter, r *http.Request) {
+		FromRequest(r).Go(func(context.Context) error { return errors.New("boom") })
+	})
+	if rec.Code != http.StatusTeapot {
+		t.Fatalf("status = %d, want WithStatusFunc result", rec.Code)
+	}
+}
+
+func TestShutdownDrainsAndRefuses(t *testing.T) {
+	t.Parallel()
+	m := New(scope.FailFast)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		serve(m, func(w http.ResponseWriter, r *http.Request) {
+			FromRequest(r).Go(func(context.Context) error {
+				<-release
+				return nil
+			})
+			close(started)
+		})
+	}()
+	<-started
+
+	shut := make(chan error, 1)
+	go func() { shut <- m.Shutdown(context.Background()) }()
+	for {
+		if rec := serve(m, func(http.ResponseWriter, *http.Request) {}); rec.Code == http.StatusServiceUnavailable {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case err := <-shut:
+		t.Fatalf("Shutdown returned %v with a request in flight", err)
+	default:
+	}
+	close(release)
+	if err := <-shut; err != nil {
+		t.Fatalf("Shutdown() = %v, want nil", err)
+	}
+	<-done
+}
+
+func TestShutdownTimeoutCancelsInFlight(t *testing.T) {
+	t.Parallel()
+	m := New(scope.FailFast)
+	started := make(chan struct{})
+	var reason atomic.Value
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- serve(m, func(w http.ResponseWriter, r *http.Request) {
+			s := FromRequest(r)
+			s.Go(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})
+			close(started)
+			_ = s.Wait()
+			reason.Store(s.CancelReason())
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := m.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown() = %v, want DeadlineExceeded", err)
+	}
+	rec := <-done
+	if got := reason.Load(); got != scope.ReasonShutdown {
+		t.Fatalf("CancelReason() = %v, want shutdown", got)
+	}
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Fatalf("status = %d, want 504", rec.Code)
+	}
+}
+
+func TestWrapKeepsFlusherAndHijacker(t *testing.T) {
+	t.Parallel()
+	m := New(scope.FailFast)
+	rec := serve(m, func(w http.ResponseWriter, _ *http.Request) {
+		if _, ok := w.(http.Hijacker); !ok {
+			t.Error("wrapped writer does not implement http.Hijacker")
+		}
+		f, ok := w.(http.Flusher)
+		if !ok {
+			t.Error("wrapped writer does not implement http.Flusher")
+			return
+		}
+		_, _ = w.Write([]byte("partial"))
+		f.Flush()
+	})
+	if !rec.Flushed {
+		t.Fatal("handler flush did not reach the underlying writer")
+	}
+	if rec.Body.String() != "partial" {
+		t.Fatalf("body = %q, want partial", rec.Body.String())
+	}
+}
+
+func TestWrapEndsRequestWhenWaitRepanics(t *testing.T) {
+	t.Parallel()
+	m := New(scope.FailFast, WithScopeOptions(scope.WithRepanic(true)))
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		serve(m, func(_ http.ResponseWriter, r *http.Request) {
+			FromRequest(r).Go(func(context.Context) error { panic("task blew up") })
+		})
+	}()
+	if _, ok := recovered.(*scope.PanicError); !ok {
+		t.Fatalf("recovered %v, want the task's *PanicError", recovered)
+	}
+	if n := m.InFlight(); n != 0 {
+		t.Fatalf("InFlight() = %d after a re-panicking request, want 0", n)
+	}
+}
+
+// plainWriter hides every optional interface of the wrapped writer.
+type plainWriter struct{ http.ResponseWriter }
+
+func TestWrapIgnoresNilStatusFuncAndFailedFlush(t *testing.T) {
+	t.Parallel()
+	m := New(scope.FailFast, WithStatusFunc(nil))
+	rec := httptest.NewRecorder()
+	m.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.(http.Flusher).Flush() // not supported by plainWriter
+		FromRequest(r).Go(func(context.Context) error { return errors.New("boom") })
+	})).ServeHTTP(plainWriter{rec}, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500 after an unsupported flush", rec.Code)
+	}
+}
@@ -18,7 +18,7 @@ func New() scope.Observer { return &Observer{} }
 func (*Observer) ScopeCreated(context.Context) {}
 
 // ScopeCancelled implements [scope.Observer].
-func (*Observer) ScopeCancelled(context.Context, error) {}
+func (*Observer) ScopeCancelled(context.Context, scope.CancelReason, error) {}
 
 // ScopeJoined implements [scope.Observer].
 func (*Observer) ScopeJoined(context.Context, time.Duration) {}
@@ -3,6 +3,8 @@ package otel
 import (
 	"context"
 	"time"
+
+	"github.com/NetPo4ki/go-scope/scope"
 )
 
 // Nop is a no-op implementation of the scope.Observer interface.
@@ -16,7 +18,7 @@ func NewNop() *Nop { return &Nop{} }
 func (*Nop) ScopeCreated(context.Context) {}
 
 // ScopeCancelled is a no-op.
-func (*Nop) ScopeCancelled(context.Context, error) {}
+func (*Nop) ScopeCancelled(context.Context, scope.CancelReason, error) {}
 
 // ScopeJoined is a no-op.
 func (*Nop) ScopeJoined(context.Context, time.Duration) {}
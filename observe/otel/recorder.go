@@ -4,6 +4,8 @@ import (
 	"context"
 	"sync"
 	"time"
+
+	"github.com/NetPo4ki/go-scope/scope"
 )
 
 // EventKind classifies recorded [Observer] callbacks for testing and debugging.
@@ -20,6 +22,7 @@ const (
 // Event is one lifecycle notification observed by [Recorder].
 type Event struct {
 	Kind     EventKind
+	Reason   scope.CancelReason
 	Cause    error
 	Wait     time.Duration
 	TaskDur  time.Duration
@@ -52,8 +55,8 @@ func (r *Recorder) ScopeCreated(context.Context) {
 }
 
 // ScopeCancelled records scope cancellation.
-func (r *Recorder) ScopeCancelled(_ context.Context, cause error) {
-	r.append(Event{Kind: EventScopeCancelled, Cause: cause})
+func (r *Recorder) ScopeCancelled(_ context.Context, reason scope.CancelReason, cause error) {
+	r.append(Event{Kind: EventScopeCancelled, Reason: reason, Cause: cause})
 }
 
 // ScopeJoined records scope join completion.
@@ -52,6 +52,9 @@ func TestRecorderCancelAndJoin(t *testing.T) {
 		switch e.Kind {
 		case EventScopeCancelled:
 			seenCancel = true
+			if e.Reason != scope.ReasonManual {
+				t.Fatalf("expected manual cancel reason, got %v", e.Reason)
+			}
 		case EventScopeJoined:
 			seenJoin = true
 		}
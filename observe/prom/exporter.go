@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/NetPo4ki/go-scope/scope"
 )
 
 // Metric name fragments used with Namespace "scope" (full names: scope_*).
@@ -18,8 +20,12 @@ const (
 	MetricActiveScopes        = "active_scopes"
 	MetricTaskDurationSeconds = "task_duration_seconds"
 	MetricJoinLatencySeconds  = "join_latency_seconds"
+	MetricScopesCanceledTotal = "scopes_canceled_total"
 )
 
+// LabelReason is the label carrying [scope.CancelReason] on scopes_canceled_total.
+const LabelReason = "reason"
+
 // Exporter implements [scope.Observer] and records metrics to a Prometheus
 // [prometheus.Registerer].
 type Exporter struct {
@@ -31,6 +37,7 @@ type Exporter struct {
 	activeScopes   prometheus.Gauge
 	taskDuration   prometheus.Histogram
 	joinLatency    prometheus.Histogram
+	scopesCanceled *prometheus.CounterVec
 }
 
 // NewExporter builds an Exporter, registers its collectors with reg, and returns
@@ -79,11 +86,17 @@ func NewExporter(reg prometheus.Registerer) (*Exporter, error) {
 			Help:      "Scope Wait blocking duration in seconds.",
 			Buckets:   prometheus.DefBuckets,
 		}),
+		scopesCanceled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "scope",
+			Name:      MetricScopesCanceledTotal,
+			Help:      "Total scopes canceled, labeled by cancellation reason.",
+		}, []string{LabelReason}),
 	}
 
 	for _, c := range []prometheus.Collector{
 		e.tasksStarted, e.tasksCompleted, e.tasksFailed, e.tasksCanceled,
 		e.activeTasks, e.activeScopes, e.taskDuration, e.joinLatency,
+		e.scopesCanceled,
 	} {
 		if err := reg.Register(c); err != nil {
 			return nil, err
@@ -99,8 +112,9 @@ func (e *Exporter) ScopeCreated(context.Context) {
 }
 
 // ScopeCancelled implements [scope.Observer].
-func (e *Exporter) ScopeCancelled(context.Context, error) {
+func (e *Exporter) ScopeCancelled(_ context.Context, reason scope.CancelReason, _ error) {
 	// Scope-level cancel does not increment task_* counters; tasks record outcome.
+	e.scopesCanceled.WithLabelValues(reason.String()).Inc()
 }
 
 // ScopeJoined implements [scope.Observer].
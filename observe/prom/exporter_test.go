@@ -100,3 +100,27 @@ func TestExporterDoubleRegisterFails(t *testing.T) {
 		t.Fatal("expected second NewExporter to fail with duplicate registration")
 	}
 }
+
+func TestExporterScopeCanceledByReason(t *testing.T) {
+	t.Parallel()
+	reg := prometheus.NewPedanticRegistry()
+	exp, err := NewExporter(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manual := scope.New(context.Background(), scope.Supervisor, scope.WithObserver(exp))
+	manual.Cancel(nil)
+	_ = manual.Wait()
+
+	failing := scope.New(context.Background(), scope.FailFast, scope.WithObserver(exp))
+	failing.Go(func(_ context.Context) error { return errors.New("x") })
+	_ = failing.Wait()
+
+	if v := testutil.ToFloat64(exp.scopesCanceled.WithLabelValues(scope.ReasonManual.String())); v != 1 {
+		t.Fatalf("scopes_canceled{reason=manual}: want 1 got %v", v)
+	}
+	if v := testutil.ToFloat64(exp.scopesCanceled.WithLabelValues(scope.ReasonSiblingFailure.String())); v != 1 {
+		t.Fatalf("scopes_canceled{reason=sibling_failure}: want 1 got %v", v)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"sync/atomic"
 	"time"
+
+	"github.com/NetPo4ki/go-scope/scope"
 )
 
 // Metrics is a lightweight in-memory observer that maintains counters and simple sums.
@@ -33,7 +35,7 @@ func (m *Metrics) ScopeCreated(_ context.Context) {
 }
 
 // ScopeCancelled records scope cancellation.
-func (m *Metrics) ScopeCancelled(_ context.Context, _ error) {
+func (m *Metrics) ScopeCancelled(_ context.Context, _ scope.CancelReason, _ error) {
 	m.scopesCancelled.Add(1)
 }
 
@@ -0,0 +1,67 @@
+package scope
+
+import (
+	"context"
+	"time"
+
+	"github.com/NetPo4ki/go-scope/scope/internal"
+)
+
+// GoAfter starts fn as a task of the scope once d has elapsed.
+//
+// The pending task counts toward Wait from the moment GoAfter returns nil.
+// If the scope is canceled before the task is due, the task is dropped
+// without running and without an error. All delayed tasks of a scope share a
+// single runtime timer.
+func (s *Scope) GoAfter(d time.Duration, fn func(ctx context.Context) error) error {
+	return s.GoAt(time.Now().Add(d), fn)
+}
+
+// GoAt is like GoAfter but starts fn at the wall-clock time t, or as soon as
+// possible if t has passed.
+func (s *Scope) GoAt(t time.Time, fn func(ctx context.Context) error) error {
+	if fn == nil {
+		return ErrNotAdmitted
+	}
+	s.mu.Lock()
+	if err := s.reserveLocked(); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	if s.timers == nil {
+		s.timers = &internal.TimerQueue{}
+		q := s.timers
+		s.stopTimers = context.AfterFunc(s.ctx, func() {
+			for n := q.Stop(); n > 0; n-- {
+				s.taskDone()
+			}
+		})
+	}
+	q := s.timers
+	s.mu.Unlock()
+
+	// The pending slot is released only after the task has been spawned, so
+	// Wait cannot observe an idle scope in between.
+	if !q.Add(t, func() {
+		if s.ctx.Err() == nil {
+			_ = s.spawn("", fn)
+		}
+		s.taskDone()
+	}) {
+		s.taskDone()
+		return ErrScopeCanceled
+	}
+	return nil
+}
+
+// dropTimers releases the cancellation hook of the delayed-task queue once
+// every task has been joined.
+func (s *Scope) dropTimers() {
+	s.mu.Lock()
+	stop := s.stopTimers
+	s.stopTimers = nil
+	s.mu.Unlock()
+	if stop != nil {
+		stop()
+	}
+}
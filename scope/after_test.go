@@ -0,0 +1,95 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGoAfterRunsInOrderAndCountsTowardWait(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast)
+	var mu sync.Mutex
+	var order []int
+	record := func(i int) func(context.Context) error {
+		return func(context.Context) error {
+			mu.Lock()
+			order = append(order, i)
+			mu.Unlock()
+			return nil
+		}
+	}
+	start := time.Now()
+	_ = s.GoAfter(30*time.Millisecond, record(3))
+	_ = s.GoAfter(10*time.Millisecond, record(1))
+	_ = s.GoAt(time.Now().Add(20*time.Millisecond), record(2))
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v", err)
+	}
+	if time.Since(start) < 30*time.Millisecond {
+		t.Fatal("Wait returned before the delayed tasks ran")
+	}
+	if len(order) != 3 || order[0] != 1 || order[1] != 2 || order[2] != 3 {
+		t.Fatalf("order = %v, want [1 2 3]", order)
+	}
+}
+
+func TestGoAfterDroppedOnCancel(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast)
+	var ran atomic.Bool
+	for i := 0; i < 100; i++ {
+		if err := s.GoAfter(time.Hour, func(context.Context) error {
+			ran.Store(true)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.AfterFunc(5*time.Millisecond, func() { s.Cancel(nil) })
+	done := make(chan error, 1)
+	go func() { done <- s.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Wait() = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wait blocked on dropped delayed tasks")
+	}
+	if ran.Load() {
+		t.Fatal("delayed task ran after cancel")
+	}
+	if err := s.GoAfter(0, func(context.Context) error { return nil }); !errors.Is(err, ErrScopeCanceled) {
+		t.Fatalf("GoAfter after cancel = %v, want ErrScopeCanceled", err)
+	}
+}
+
+func TestGoAfterDroppedOnParentCancel(t *testing.T) {
+	t.Parallel()
+	parent, cancel := context.WithCancel(context.Background())
+	s := New(parent, Supervisor)
+	var ran atomic.Bool
+	_ = s.GoAfter(time.Hour, func(context.Context) error {
+		ran.Store(true)
+		return nil
+	})
+	cancel()
+	_ = s.Wait()
+	if ran.Load() {
+		t.Fatal("delayed task ran after parent cancel")
+	}
+}
+
+func TestGoAtPastTimeRunsPromptly(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	s := New(context.Background(), FailFast)
+	_ = s.GoAt(time.Now().Add(-time.Hour), func(context.Context) error { return boom })
+	if err := s.Wait(); !errors.Is(err, boom) {
+		t.Fatalf("Wait() = %v, want boom", err)
+	}
+}
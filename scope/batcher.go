@@ -0,0 +1,239 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBatcherClosed is returned by Batcher.Add after Close, after a graceful
+// shutdown was requested, or once the scope has been canceled.
+var ErrBatcherClosed = errors.New("scope: batcher closed")
+
+// BatchOption configures NewBatcher.
+type BatchOption func(*batchOptions)
+
+type batchOptions struct {
+	name     string
+	size     int
+	interval time.Duration
+	buffer   int
+	retry    func(attempt int, err error) (time.Duration, bool)
+}
+
+// BatchName sets the task name reported for every flush.
+func BatchName(name string) BatchOption { return func(o *batchOptions) { o.name = name } }
+
+// BatchSize flushes as soon as n items are pending (n>0, default 100).
+func BatchSize(n int) BatchOption { return func(o *batchOptions) { o.size = n } }
+
+// BatchInterval flushes pending items d after the first of them was added
+// (d>0, default 1s).
+func BatchInterval(d time.Duration) BatchOption { return func(o *batchOptions) { o.interval = d } }
+
+// BatchBuffer sets how many added items may wait while a flush is in
+// progress before Add blocks (n>0, default the batch size).
+func BatchBuffer(n int) BatchOption { return func(o *batchOptions) { o.buffer = n } }
+
+// BatchRetry is called after a failed flush with the 1-based attempt number
+// and its error. It returns how long to wait before retrying and whether to
+// retry at all. By default failed flushes are not retried.
+func BatchRetry(fn func(attempt int, err error) (wait time.Duration, retry bool)) BatchOption {
+	return func(o *batchOptions) { o.retry = fn }
+}
+
+// Batcher collects items from many goroutines and hands them in batches to a
+// flush function running as a task of its scope.
+type Batcher[T any] struct {
+	s     *Scope
+	flush func(ctx context.Context, batch []T) error
+	o     batchOptions
+	items chan T
+
+	mu      sync.Mutex
+	closed  bool
+	adds    sync.WaitGroup // Add calls admitted before Close
+	closing chan struct{}
+	exited  chan struct{}
+}
+
+// NewBatcher starts a Batcher owned by s. Batches are flushed when BatchSize
+// items are pending or BatchInterval after the first pending item, one flush
+// at a time. Each flush is a task of s: it is admitted by the Limiter,
+// reported to the Observer, and a flush that still fails after BatchRetry
+// gives up is reported to the Policy with TaskFailure.Attempt set to the
+// number of attempts.
+//
+// The Batcher counts toward Wait until it is closed. Close, or a graceful
+// shutdown started with RequestShutdown, stops accepting items and flushes
+// the remaining ones before Wait returns. When s is canceled, pending items
+// are dropped.
+func NewBatcher[T any](s *Scope, flush func(ctx context.Context, batch []T) error, optFns ...BatchOption) (*Batcher[T], error) {
+	if flush == nil {
+		return nil, ErrNotAdmitted
+	}
+	o := batchOptions{size: 100, interval: time.Second}
+	for _, f := range optFns {
+		if f != nil {
+			f(&o)
+		}
+	}
+	o.size = max(o.size, 1)
+	if o.interval <= 0 {
+		o.interval = time.Second
+	}
+	if o.buffer <= 0 {
+		o.buffer = o.size
+	}
+	b := &Batcher[T]{
+		s:       s,
+		flush:   flush,
+		o:       o,
+		items:   make(chan T, o.buffer),
+		closing: make(chan struct{}),
+		exited:  make(chan struct{}),
+	}
+	s.mu.Lock()
+	if err := s.reserveLocked(); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.mu.Unlock()
+	go b.loop()
+	go func() {
+		select {
+		case <-s.ShutdownRequested():
+			b.Close()
+		case <-b.exited:
+		}
+	}()
+	return b, nil
+}
+
+// Add queues item, blocking while the buffer is full. It returns ctx's error
+// if ctx is done first, or ErrBatcherClosed if the Batcher no longer accepts
+// items, including when it is closed while Add is blocked.
+func (b *Batcher[T]) Add(ctx context.Context, item T) error {
+	b.mu.Lock()
+	if b.closed || b.s.ctx.Err() != nil {
+		b.mu.Unlock()
+		return ErrBatcherClosed
+	}
+	b.adds.Add(1)
+	b.mu.Unlock()
+	defer b.adds.Done()
+	select {
+	case b.items <- item:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-b.closing:
+		return ErrBatcherClosed
+	case <-b.s.ctx.Done():
+		return ErrBatcherClosed
+	}
+}
+
+// Close stops accepting items. Items already added are flushed; Wait on the
+// scope returns after that final flush. Close does not wait and is safe to
+// call more than once.
+func (b *Batcher[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.closed {
+		b.closed = true
+		close(b.closing)
+	}
+}
+
+func (b *Batcher[T]) loop() {
+	defer b.s.taskDone()
+	defer close(b.exited)
+	timer := time.NewTimer(b.o.interval)
+	timer.Stop()
+	defer timer.Stop()
+	batch := make([]T, 0, b.o.size)
+	flush := func() {
+		timer.Stop()
+		if len(batch) > 0 {
+			b.flushBatch(batch)
+			batch = make([]T, 0, b.o.size)
+		}
+	}
+	for {
+		select {
+		case item := <-b.items:
+			batch = append(batch, item)
+			if len(batch) == 1 {
+				timer.Reset(b.o.interval)
+			}
+			if len(batch) >= b.o.size {
+				flush()
+			}
+		case <-timer.C:
+			flush()
+		case <-b.closing:
+			// Blocked Adds give up once closing is closed and no new ones
+			// are admitted, so after adds.Wait every accepted item is in
+			// the buffer.
+			b.adds.Wait()
+		drain:
+			for {
+				select {
+				case item := <-b.items:
+					batch = append(batch, item)
+					if len(batch) >= b.o.size {
+						flush()
+					}
+				default:
+					break drain
+				}
+			}
+			flush()
+			return
+		case <-b.s.ctx.Done():
+			return
+		}
+	}
+}
+
+// flushBatch runs one flush task and waits for it, so that flushes never
+// overlap and producers are held back by the buffer meanwhile.
+func (b *Batcher[T]) flushBatch(batch []T) {
+	done := make(chan struct{})
+	attempts := 0
+	if b.s.spawnTask(taskSpec{
+		name:   b.o.name,
+		report: func(err error) (error, int) { return err, attempts },
+		fn: func(ctx context.Context) error {
+			defer close(done)
+			for {
+				attempts++
+				err := b.flush(ctx, batch)
+				if err == nil || b.o.retry == nil {
+					return err
+				}
+				wait, retry := b.o.retry(attempts, err)
+				if !retry {
+					return err
+				}
+				t := time.NewTimer(wait)
+				select {
+				case <-t.C:
+				case <-ctx.Done():
+					t.Stop()
+					return err
+				}
+			}
+		},
+	}) != nil {
+		return
+	}
+	// A flush refused by the Limiter never runs; cancellation is the only
+	// way that happens, and the loop then stops anyway.
+	select {
+	case <-done:
+	case <-b.s.ctx.Done():
+	}
+}
@@ -0,0 +1,197 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type batchLog struct {
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (l *batchLog) flush(_ context.Context, batch []int) error {
+	l.mu.Lock()
+	l.batches = append(l.batches, batch)
+	l.mu.Unlock()
+	return nil
+}
+
+func (l *batchLog) sizes() []int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var out []int
+	for _, b := range l.batches {
+		out = append(out, len(b))
+	}
+	return out
+}
+
+func TestBatcherFlushesBySizeAndOnClose(t *testing.T) {
+	t.Parallel()
+	var log batchLog
+	s := New(context.Background(), FailFast)
+	b, err := NewBatcher(s, log.flush, BatchSize(3), BatchInterval(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 7; i++ {
+		if err := b.Add(context.Background(), i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b.Close()
+	if err := b.Add(context.Background(), 99); !errors.Is(err, ErrBatcherClosed) {
+		t.Fatalf("Add after Close = %v, want ErrBatcherClosed", err)
+	}
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v", err)
+	}
+	got := log.sizes()
+	if len(got) != 3 || got[0] != 3 || got[1] != 3 || got[2] != 1 {
+		t.Fatalf("batch sizes = %v, want [3 3 1]", got)
+	}
+}
+
+func TestBatcherFlushesByInterval(t *testing.T) {
+	t.Parallel()
+	var log batchLog
+	s := New(context.Background(), FailFast)
+	b, _ := NewBatcher(s, log.flush, BatchSize(100), BatchInterval(5*time.Millisecond))
+	_ = b.Add(context.Background(), 1)
+	_ = b.Add(context.Background(), 2)
+	deadline := time.Now().Add(5 * time.Second)
+	for len(log.sizes()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("interval flush did not happen")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	b.Close()
+	_ = s.Wait()
+	if got := log.sizes(); len(got) != 1 || got[0] != 2 {
+		t.Fatalf("batch sizes = %v, want [2]", got)
+	}
+}
+
+func TestBatcherBlocksProducersWhileFull(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	s := New(context.Background(), FailFast)
+	b, _ := NewBatcher(s, func(context.Context, []int) error {
+		<-release
+		return nil
+	}, BatchSize(1), BatchBuffer(1))
+	_ = b.Add(context.Background(), 1) // taken by the blocked flush
+	_ = b.Add(context.Background(), 2) // fills the buffer
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := b.Add(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Add on a full buffer = %v, want DeadlineExceeded", err)
+	}
+	close(release)
+	b.Close()
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v", err)
+	}
+}
+
+func TestBatcherCloseReleasesBlockedProducer(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	s := New(context.Background(), FailFast)
+	b, _ := NewBatcher(s, func(context.Context, []int) error {
+		<-release
+		return nil
+	}, BatchSize(1), BatchBuffer(1))
+	_ = b.Add(context.Background(), 1) // taken by the blocked flush
+	_ = b.Add(context.Background(), 2) // fills the buffer
+	added := make(chan error, 1)
+	go func() { added <- b.Add(context.Background(), 3) }()
+	time.Sleep(10 * time.Millisecond)
+
+	closed := make(chan struct{})
+	go func() {
+		b.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close blocked behind a producer waiting in Add")
+	}
+	select {
+	case err := <-added:
+		if !errors.Is(err, ErrBatcherClosed) {
+			t.Fatalf("blocked Add = %v, want ErrBatcherClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("blocked Add did not return after Close")
+	}
+	close(release)
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v", err)
+	}
+}
+
+func TestBatcherRetriesAndReportsAttempts(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	var calls int
+	s := New(context.Background(), Supervisor)
+	b, _ := NewBatcher(s, func(context.Context, []int) error {
+		calls++
+		return boom
+	}, BatchSize(1), BatchName("store"), BatchRetry(func(attempt int, err error) (time.Duration, bool) {
+		return time.Millisecond, attempt < 3
+	}))
+	_ = b.Add(context.Background(), 1)
+	b.Close()
+	err := s.Wait()
+	var se *ScopeError
+	if !errors.As(err, &se) || se.Count() != 1 {
+		t.Fatalf("Wait() = %v, want one failure", err)
+	}
+	if f := se.Failures()[0]; f.Name != "store" || f.Attempt != 3 || !errors.Is(f.Err, boom) || calls != 3 {
+		t.Fatalf("failure = %+v after %d calls, want store failing after 3 attempts", f, calls)
+	}
+}
+
+func TestBatcherFlushesOnGracefulShutdown(t *testing.T) {
+	t.Parallel()
+	var log batchLog
+	s := New(context.Background(), FailFast)
+	b, _ := NewBatcher(s, log.flush, BatchInterval(time.Hour))
+	for i := 0; i < 5; i++ {
+		_ = b.Add(context.Background(), i)
+	}
+	s.RequestShutdown(nil)
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v", err)
+	}
+	if got := log.sizes(); len(got) != 1 || got[0] != 5 {
+		t.Fatalf("batch sizes = %v, want [5]", got)
+	}
+}
+
+func TestBatcherDropsOnCancel(t *testing.T) {
+	t.Parallel()
+	var log batchLog
+	s := New(context.Background(), FailFast)
+	b, _ := NewBatcher(s, log.flush, BatchInterval(time.Hour))
+	_ = b.Add(context.Background(), 1)
+	s.Cancel(nil)
+	_ = s.Wait()
+	if got := log.sizes(); len(got) != 0 {
+		t.Fatalf("batch sizes = %v, want no flush after cancel", got)
+	}
+	if err := b.Add(context.Background(), 2); !errors.Is(err, ErrBatcherClosed) {
+		t.Fatalf("Add after cancel = %v, want ErrBatcherClosed", err)
+	}
+	if _, err := NewBatcher(s, log.flush); !errors.Is(err, ErrScopeCanceled) {
+		t.Fatalf("NewBatcher after cancel = %v, want ErrScopeCanceled", err)
+	}
+}
@@ -0,0 +1,81 @@
+package scope
+
+import (
+	"context"
+	"io"
+)
+
+type closerReg struct {
+	stop func() bool
+}
+
+// CloseOnCancel closes c when the scope is canceled, which unblocks tasks
+// stuck in I/O that does not observe ctx.Done, such as net.Conn.Read or a pipe
+// read. If the scope is already canceled, c is closed right away. Errors from
+// Close are ignored.
+//
+// The returned stop function unregisters c and reports whether it did so
+// before c was closed. Call it when the resource no longer needs the scope's
+// protection; GoCloser does so for a task automatically.
+//
+// Registrations still pending when Wait has joined every task are dropped
+// without closing c. The same applies to a registration made after that
+// point, for example from a Defer hook: unless the scope was canceled, c is
+// left open for the caller to close, and stop reports false.
+func (s *Scope) CloseOnCancel(c io.Closer) (stop func() bool) {
+	if c == nil {
+		return func() bool { return false }
+	}
+	reg := &closerReg{}
+	s.mu.Lock()
+	if s.closersDropped {
+		canceled := s.canceledLocked()
+		s.mu.Unlock()
+		if canceled {
+			_ = c.Close()
+		}
+		return func() bool { return false }
+	}
+	reg.stop = context.AfterFunc(s.ctx, func() { _ = c.Close() })
+	if s.closers == nil {
+		s.closers = make(map[*closerReg]struct{})
+	}
+	s.closers[reg] = struct{}{}
+	s.mu.Unlock()
+	return func() bool {
+		s.mu.Lock()
+		delete(s.closers, reg)
+		s.mu.Unlock()
+		return reg.stop()
+	}
+}
+
+// GoCloser starts fn as a task of the scope with c registered by
+// CloseOnCancel while fn runs, so a task blocked on c is unblocked when the
+// scope is canceled. The registration is removed once the task has finished,
+// including when it panics; c is not closed otherwise.
+func (s *Scope) GoCloser(c io.Closer, fn func(ctx context.Context) error) error {
+	if c == nil || fn == nil {
+		return ErrNotAdmitted
+	}
+	stop := func() bool { return false }
+	return s.spawnTask(taskSpec{
+		fn: func(ctx context.Context) error {
+			stop = s.CloseOnCancel(c)
+			return fn(ctx)
+		},
+		after: func() { stop() },
+	})
+}
+
+// dropClosers unregisters every pending CloseOnCancel registration.
+func (s *Scope) dropClosers() {
+	s.mu.Lock()
+	closers := s.closers
+	s.closers = nil
+	s.closersDropped = true
+	s.mu.Unlock()
+	for reg := range closers {
+		reg.stop()
+	}
+}
@@ -0,0 +1,138 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type countingCloser struct{ closed atomic.Int32 }
+
+func (c *countingCloser) Close() error {
+	c.closed.Add(1)
+	return nil
+}
+
+func TestCloseOnCancelUnblocksRead(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	s := New(context.Background(), FailFast)
+	client, server := net.Pipe()
+	defer server.Close()
+
+	s.Go(func(_ context.Context) error {
+		stop := s.CloseOnCancel(client)
+		defer stop()
+		_, err := client.Read(make([]byte, 1)) // ignores ctx; only Close unblocks it
+		return err
+	})
+	s.Go(func(_ context.Context) error {
+		time.Sleep(10 * time.Millisecond)
+		return boom
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- s.Wait() }()
+	select {
+	case err := <-done:
+		if !errors.Is(err, boom) {
+			t.Fatalf("expected root cause, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wait hung: blocked read was not unblocked by cancellation")
+	}
+}
+
+func TestCloseOnCancelStopAndJoin(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), Supervisor)
+	stopped := &countingCloser{}
+	stop := s.CloseOnCancel(stopped)
+	if !stop() {
+		t.Fatal("stop should unregister a pending closer")
+	}
+	joined := &countingCloser{}
+	s.CloseOnCancel(joined)
+	if err := s.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	s.Cancel(nil)
+
+	canceled := New(context.Background(), Supervisor)
+	canceled.Cancel(nil)
+	immediate := &countingCloser{}
+	canceled.CloseOnCancel(immediate)
+	_ = canceled.Wait()
+
+	time.Sleep(10 * time.Millisecond) // AfterFunc closes run asynchronously
+	if stopped.closed.Load() != 0 || joined.closed.Load() != 0 {
+		t.Fatal("unregistered closers must not be closed")
+	}
+	if immediate.closed.Load() != 1 {
+		t.Fatal("closer registered on a canceled scope should be closed")
+	}
+}
+
+func TestGoCloserUnregistersWithTask(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	s := New(context.Background(), FailFast)
+	client, server := net.Pipe()
+	defer server.Close()
+	if err := s.GoCloser(client, func(_ context.Context) error {
+		_, err := client.Read(make([]byte, 1))
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	finished := &countingCloser{}
+	if err := s.GoCloser(finished, func(_ context.Context) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	s.Go(func(_ context.Context) error { return boom })
+
+	done := make(chan error, 1)
+	go func() { done <- s.Wait() }()
+	select {
+	case err := <-done:
+		if !errors.Is(err, boom) {
+			t.Fatalf("Wait() = %v, want boom", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wait hung: GoCloser did not close the blocked task's conn")
+	}
+	time.Sleep(10 * time.Millisecond) // AfterFunc closes run asynchronously
+	if finished.closed.Load() != 0 {
+		t.Fatal("closer of a finished task must not be closed on cancel")
+	}
+}
+
+func TestCloseOnCancelDuringTeardown(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast)
+	late := &countingCloser{}
+	var stopped bool
+	s.Defer(func(context.Context) error {
+		stopped = s.CloseOnCancel(late)()
+		return nil
+	})
+	if err := s.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if late.closed.Load() != 0 || stopped {
+		t.Fatal("closer registered during teardown of a live scope should be left open")
+	}
+
+	canceled := New(context.Background(), FailFast)
+	canceled.Cancel(nil)
+	_ = canceled.Wait()
+	after := &countingCloser{}
+	canceled.CloseOnCancel(after)
+	if after.closed.Load() != 1 {
+		t.Fatal("closer registered after a canceled scope was joined should be closed")
+	}
+}
@@ -0,0 +1,239 @@
+// Package cron runs jobs on cron schedules inside a [scope.Scope].
+//
+// A Scheduler owns a Supervisor child of the scope it is created from. Each
+// job run is a task of that child scope, so a failing run is recorded without
+// disturbing other jobs, and canceling or shutting down the parent stops the
+// schedule and cancels the runs in flight; the parent's Wait joins them.
+// Time is read through a Clock, which tests can replace with WithClock.
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/NetPo4ki/go-scope/scope"
+)
+
+// ErrReplaced is the cancellation cause of a run replaced by a newer run
+// under the Replace concurrency policy.
+var ErrReplaced = errors.New("cron: run replaced by a newer run")
+
+// MaxCatchUp bounds the number of missed runs started at once under
+// CatchUp, and the number of missed activations scanned under SkipMissed.
+const MaxCatchUp = 1000
+
+// Clock is the time source of a Scheduler.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// MissedPolicy controls activations that passed while the scheduler could
+// not fire, for example after a process stall or a clock jump.
+type MissedPolicy int
+
+const (
+	// SkipMissed runs the job once for the latest missed activation.
+	SkipMissed MissedPolicy = iota
+	// CatchUp runs the job once for every missed activation.
+	CatchUp
+)
+
+// ConcurrencyPolicy controls a run that is due while earlier runs of the
+// same job are still in progress.
+type ConcurrencyPolicy int
+
+const (
+	// Allow starts the new run alongside the previous ones.
+	Allow ConcurrencyPolicy = iota
+	// Forbid skips the new run.
+	Forbid
+	// Replace cancels the previous run with ErrReplaced and starts the new one.
+	Replace
+)
+
+// Option configures a Scheduler.
+type Option func(*Scheduler)
+
+// WithLocation sets the default time zone of schedules (time.Local by
+// default). A CRON_TZ prefix in a spec takes precedence.
+func WithLocation(loc *time.Location) Option { return func(c *Scheduler) { c.loc = loc } }
+
+// WithClock replaces the wall clock, typically with a fake in tests.
+func WithClock(clock Clock) Option { return func(c *Scheduler) { c.clock = clock } }
+
+// WithScopeOptions sets the options of the scheduler's scope, for example
+// scope.WithMaxConcurrency or scope.WithObserver.
+func WithScopeOptions(optFns ...scope.Option) Option {
+	return func(c *Scheduler) { c.opts = append(c.opts, optFns...) }
+}
+
+// JobOption configures a job added with Add.
+type JobOption func(*job)
+
+// WithName sets the task name reported for the job's runs.
+func WithName(name string) JobOption { return func(j *job) { j.name = name } }
+
+// WithMissedPolicy sets the MissedPolicy (SkipMissed by default).
+func WithMissedPolicy(p MissedPolicy) JobOption { return func(j *job) { j.missed = p } }
+
+// WithConcurrencyPolicy sets the ConcurrencyPolicy (Allow by default).
+func WithConcurrencyPolicy(p ConcurrencyPolicy) JobOption {
+	return func(j *job) { j.concurrency = p }
+}
+
+// Scheduler runs jobs on cron schedules.
+type Scheduler struct {
+	scope *scope.Scope
+	// loops owns the scheduling loops, outside of the limiter and observer
+	// configured for runs.
+	loops *scope.Scope
+	clock Clock
+	loc   *time.Location
+	opts  []scope.Option
+}
+
+type job struct {
+	sched       *Schedule
+	fn          func(ctx context.Context) error
+	name        string
+	missed      MissedPolicy
+	concurrency ConcurrencyPolicy
+
+	mu      sync.Mutex
+	running int
+	cancel  context.CancelCauseFunc // latest run, for Replace
+}
+
+type scheduledKey struct{}
+
+// ScheduledTime returns the activation time of the run carrying ctx.
+func ScheduledTime(ctx context.Context) (time.Time, bool) {
+	t, ok := ctx.Value(scheduledKey{}).(time.Time)
+	return t, ok
+}
+
+// New creates a Scheduler whose jobs run in a Supervisor child of parent.
+func New(parent *scope.Scope, optFns ...Option) *Scheduler {
+	c := &Scheduler{clock: realClock{}, loc: time.Local}
+	for _, fn := range optFns {
+		if fn != nil {
+			fn(c)
+		}
+	}
+	c.scope = parent.Child(scope.Supervisor, c.opts...)
+	c.loops = c.scope.Child(scope.Supervisor, scope.WithMaxConcurrency(0), scope.WithObserver(nil))
+	// A child scope is joined as soon as it has no tasks, so hold the loops
+	// scope open for later Add calls until Stop or the parent cancels it.
+	c.loops.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+	return c
+}
+
+// Scope returns the scope that owns the scheduler's runs.
+func (c *Scheduler) Scope() *scope.Scope { return c.scope }
+
+// Add parses spec and schedules fn, starting from the current time. It
+// returns a parse error wrapping ErrInvalidSpec, or the scope's admission
+// error if the scheduler has been stopped.
+func (c *Scheduler) Add(spec string, fn func(ctx context.Context) error, optFns ...JobOption) error {
+	sched, err := ParseInLocation(spec, c.loc)
+	if err != nil {
+		return err
+	}
+	if fn == nil {
+		return scope.ErrNotAdmitted
+	}
+	j := &job{sched: sched, fn: fn}
+	for _, f := range optFns {
+		if f != nil {
+			f(j)
+		}
+	}
+	return c.loops.TryGoErr(func(ctx context.Context) error {
+		c.loop(ctx, j)
+		return nil
+	})
+}
+
+// Stop stops scheduling, cancels runs in flight, and waits for them. It
+// returns the scheduler scope's Wait error, which lists failed runs.
+func (c *Scheduler) Stop() error {
+	c.scope.Shutdown(nil)
+	return c.scope.Wait()
+}
+
+// loop waits for each activation of j and starts its runs until ctx is done.
+func (c *Scheduler) loop(ctx context.Context, j *job) {
+	last := c.clock.Now()
+	for {
+		next := j.sched.Next(last)
+		if next.IsZero() {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.clock.After(next.Sub(c.clock.Now())):
+		}
+
+		now := c.clock.Now()
+		due := []time.Time{next}
+		for t := j.sched.Next(next); !t.IsZero() && !t.After(now); t = j.sched.Next(t) {
+			if len(due) == MaxCatchUp {
+				next = now
+				break
+			}
+			due = append(due, t)
+			next = t
+		}
+		if j.missed == SkipMissed {
+			due = due[len(due)-1:]
+		}
+		for _, at := range due {
+			c.start(j, at)
+		}
+		last = next
+	}
+}
+
+// start runs j for the activation at, following its ConcurrencyPolicy.
+func (c *Scheduler) start(j *job, at time.Time) {
+	j.mu.Lock()
+	switch {
+	case j.concurrency == Forbid && j.running > 0:
+		j.mu.Unlock()
+		return
+	case j.concurrency == Replace && j.cancel != nil:
+		j.cancel(ErrReplaced)
+	}
+	runCtx, cancel := context.WithCancelCause(context.WithValue(c.scope.Context(), scheduledKey{}, at))
+	j.cancel = cancel
+	j.running++
+	j.mu.Unlock()
+
+	// A refused run leaves the bookkeeping behind, which is harmless: the
+	// scope is canceled and the loop is about to return.
+	c.scope.GoNamed(j.name, func(context.Context) error {
+		defer func() {
+			cancel(nil)
+			j.mu.Lock()
+			j.running--
+			j.mu.Unlock()
+		}()
+		err := j.fn(runCtx)
+		if err != nil && errors.Is(context.Cause(runCtx), ErrReplaced) {
+			return nil
+		}
+		return err
+	})
+}
@@ -0,0 +1,251 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NetPo4ki/go-scope/scope"
+)
+
+// fakeClock is a manually advanced Clock.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward once a waiter is registered, then fires
+// every waiter that became due.
+func (c *fakeClock) Advance(t *testing.T, d time.Duration) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		c.mu.Lock()
+		if len(c.waiters) > 0 {
+			break
+		}
+		c.mu.Unlock()
+		if time.Now().After(deadline) {
+			t.Fatal("no scheduler waiting on the clock")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	c.now = c.now.Add(d)
+	kept := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			kept = append(kept, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = kept
+	c.mu.Unlock()
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSchedulerMissedPolicy(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name   string
+		policy MissedPolicy
+		want   []int // minutes of the scheduled times
+	}{
+		{"skip", SkipMissed, []int{1, 4}},
+		{"catch-up", CatchUp, []int{1, 2, 3, 4}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			clock := newFakeClock()
+			s := scope.New(context.Background(), scope.FailFast)
+			c := New(s, WithClock(clock), WithLocation(time.UTC))
+			var mu sync.Mutex
+			var got []int
+			err := c.Add("* * * * *", func(ctx context.Context) error {
+				at, _ := ScheduledTime(ctx)
+				mu.Lock()
+				got = append(got, at.Minute())
+				mu.Unlock()
+				return nil
+			}, WithMissedPolicy(tc.policy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			clock.Advance(t, time.Minute)
+			waitFor(t, "first run", func() bool { mu.Lock(); defer mu.Unlock(); return len(got) == 1 })
+			clock.Advance(t, 3*time.Minute) // the scheduler stalled over two activations
+			waitFor(t, "missed runs", func() bool { mu.Lock(); defer mu.Unlock(); return len(got) == len(tc.want) })
+			if err := c.Stop(); err != nil {
+				t.Fatalf("Stop() = %v", err)
+			}
+			_ = s.Wait()
+			mu.Lock()
+			defer mu.Unlock()
+			slices.Sort(got) // caught-up runs may start in any order
+			for i := range tc.want {
+				if got[i] != tc.want[i] {
+					t.Fatalf("runs at minutes %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSchedulerConcurrencyPolicy(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		policy   ConcurrencyPolicy
+		starts   int32
+		replaced int32
+	}{
+		{"allow", Allow, 2, 0},
+		{"forbid", Forbid, 1, 0},
+		{"replace", Replace, 2, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			clock := newFakeClock()
+			s := scope.New(context.Background(), scope.FailFast)
+			c := New(s, WithClock(clock))
+			var starts, replaced atomic.Int32
+			_ = c.Add("* * * * *", func(ctx context.Context) error {
+				starts.Add(1)
+				<-ctx.Done()
+				if errors.Is(context.Cause(ctx), ErrReplaced) {
+					replaced.Add(1)
+				}
+				return ctx.Err()
+			}, WithConcurrencyPolicy(tc.policy))
+			clock.Advance(t, time.Minute)
+			waitFor(t, "first run", func() bool { return starts.Load() == 1 })
+			clock.Advance(t, time.Minute)
+			if tc.starts > 1 {
+				waitFor(t, "second run", func() bool { return starts.Load() == tc.starts })
+			}
+			waitFor(t, "replacement", func() bool { return replaced.Load() == tc.replaced })
+			clock.Advance(t, 0) // the loop is waiting again, so the second activation was handled
+			s.Shutdown(nil)
+			if err := s.Wait(); err != nil {
+				t.Fatalf("Wait() = %v", err)
+			}
+			if starts.Load() != tc.starts {
+				t.Fatalf("starts = %d, want %d", starts.Load(), tc.starts)
+			}
+		})
+	}
+}
+
+func TestSchedulerFailuresAreSupervised(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	clock := newFakeClock()
+	s := scope.New(context.Background(), scope.FailFast)
+	c := New(s, WithClock(clock))
+	var failing, healthy atomic.Int32
+	_ = c.Add("* * * * *", func(context.Context) error {
+		failing.Add(1)
+		return boom
+	}, WithName("flaky"))
+	_ = c.Add("*/2 * * * *", func(context.Context) error {
+		healthy.Add(1)
+		return nil
+	})
+	for i := 0; i < 4; i++ {
+		clock.Advance(t, time.Minute)
+		waitFor(t, "flaky run", func() bool { return failing.Load() == int32(i+1) })
+	}
+	waitFor(t, "healthy runs", func() bool { return healthy.Load() == 2 })
+	err := c.Stop()
+	var se *scope.ScopeError
+	if !errors.As(err, &se) || se.Count() != 4 || se.Failures()[0].Name != "flaky" {
+		t.Fatalf("Stop() = %v, want 4 flaky failures", err)
+	}
+	if err := s.Wait(); err == nil {
+		t.Fatal("parent Wait() = nil, want the scheduler's failures")
+	}
+}
+
+func TestSchedulerStopsWithParent(t *testing.T) {
+	t.Parallel()
+	s := scope.New(context.Background(), scope.FailFast)
+	c := New(s)
+	if err := c.Add("@yearly", func(context.Context) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Add("bad spec", func(context.Context) error { return nil }); !errors.Is(err, ErrInvalidSpec) {
+		t.Fatalf("Add() = %v, want ErrInvalidSpec", err)
+	}
+	s.Shutdown(nil)
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v", err)
+	}
+	if err := c.Add("* * * * *", func(context.Context) error { return nil }); err == nil {
+		t.Fatal("Add() after shutdown succeeded")
+	}
+}
+
+func TestSchedulerAcceptsJobsAddedLater(t *testing.T) {
+	t.Parallel()
+	clock := newFakeClock()
+	s := scope.New(context.Background(), scope.FailFast)
+	c := New(s, WithClock(clock))
+	time.Sleep(10 * time.Millisecond)
+	var runs atomic.Int32
+	if err := c.Add("* * * * *", func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}); err != nil {
+		t.Fatalf("Add() after a delay = %v", err)
+	}
+	clock.Advance(t, time.Minute)
+	waitFor(t, "late job run", func() bool { return runs.Load() == 1 })
+	if err := c.Stop(); err != nil {
+		t.Fatalf("Stop() = %v", err)
+	}
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	t.Parallel()
+	const layout = "2006-01-02 15:04:05 Mon"
+	cases := []struct {
+		spec, from, want string
+	}{
+		{"* * * * *", "2024-03-10 10:15:30 Sun", "2024-03-10 10:16:00 Sun"},
+		{"*/15 * * * *", "2024-03-10 10:15:00 Sun", "2024-03-10 10:30:00 Sun"},
+		{"30 * * * * *", "2024-03-10 10:15:30 Sun", "2024-03-10 10:16:30 Sun"},
+		{"0 9-17/4 * * mon-fri", "2024-03-08 17:00:00 Fri", "2024-03-11 09:00:00 Mon"},
+		{"0 0 1,15 * *", "2024-01-15 00:00:00 Mon", "2024-02-01 00:00:00 Thu"},
+		{"0 0 29 feb *", "2023-03-01 00:00:00 Wed", "2024-02-29 00:00:00 Thu"},
+		{"0 0 * * 7", "2024-03-04 00:00:00 Mon", "2024-03-10 00:00:00 Sun"},
+		// Both day fields restricted: either one matches.
+		{"0 0 13 * fri", "2024-09-01 00:00:00 Sun", "2024-09-06 00:00:00 Fri"},
+		{"0 0 13 * fri", "2024-09-06 00:00:00 Fri", "2024-09-13 00:00:00 Fri"},
+		{"@monthly", "2024-12-15 08:00:00 Sun", "2025-01-01 00:00:00 Wed"},
+		{"@hourly", "2024-12-31 23:59:59 Tue", "2025-01-01 00:00:00 Wed"},
+	}
+	for _, tc := range cases {
+		s, err := ParseInLocation(tc.spec, time.UTC)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tc.spec, err)
+		}
+		from, _ := time.ParseInLocation(layout, tc.from, time.UTC)
+		if got := s.Next(from).Format(layout); got != tc.want {
+			t.Errorf("Next(%q, %s) = %s, want %s", tc.spec, tc.from, got, tc.want)
+		}
+	}
+}
+
+func TestScheduleTimeZone(t *testing.T) {
+	t.Parallel()
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("tzdata not available:", err)
+	}
+	s, err := Parse("CRON_TZ=America/New_York 30 2 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Location().String() != "America/New_York" {
+		t.Fatalf("Location() = %v", s.Location())
+	}
+	// 02:30 does not exist on 2024-03-10 in New York; the next run is a day later.
+	from := time.Date(2024, 3, 9, 3, 0, 0, 0, ny)
+	want := time.Date(2024, 3, 11, 2, 30, 0, 0, ny)
+	if got := s.Next(from); !got.Equal(want) {
+		t.Fatalf("Next() = %v, want %v", got, want)
+	}
+	if got := s.Next(from.UTC()); got.Location() != time.UTC {
+		t.Fatalf("Next() location = %v, want the argument's", got.Location())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"* * * foo *",
+		"CRON_TZ=Nowhere/Special * * * * *",
+	} {
+		if _, err := Parse(spec); !errors.Is(err, ErrInvalidSpec) {
+			t.Errorf("Parse(%q) = %v, want ErrInvalidSpec", spec, err)
+		}
+	}
+}
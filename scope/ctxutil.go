@@ -2,23 +2,138 @@ package scope
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
+type scopeKey struct{}
+
+func withScope(ctx context.Context, s *Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, s)
+}
+
+// FromContext returns the innermost Scope whose context ctx is or derives
+// from, so code that only receives a context can spawn tasks or child scopes
+// owned by the enclosing scope. Every task runs with its scope's context.
+// It returns nil and false when ctx carries no Scope.
+//
+// The result only spawns work: it is not the *Scope itself, so a task cannot
+// reach the enclosing scope's Wait, which would wait for the task itself, or
+// its Cancel. Child scopes created through it are owned by the caller, who
+// joins them with Wait as usual.
+//
+// A Spawner attached with ContextWithSpawner is returned as is when it is
+// innermost.
+func FromContext(ctx context.Context) (Spawner, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	switch v := ctx.Value(scopeKey{}).(type) {
+	case *Scope:
+		return spawnerView{v}, true
+	case Spawner:
+		return v, true
+	default:
+		return nil, false
+	}
+}
+
+// ContextWithSpawner returns a copy of ctx in which FromContext finds sp,
+// shadowing any enclosing Scope. It lets a Spawner other than *Scope, such
+// as the fakes in package scopetest, stand in for the scope of the tasks it
+// runs. Functions that need the real Scope, such as Do, treat such a
+// context as carrying no scope.
+func ContextWithSpawner(ctx context.Context, sp Spawner) context.Context {
+	return context.WithValue(ctx, scopeKey{}, sp)
+}
+
+// spawnerView is the Spawner returned by FromContext. It holds the scope
+// unexported, so neither a type assertion nor embedding exposes Wait.
+type spawnerView struct{ s *Scope }
+
+func (v spawnerView) Go(fn func(ctx context.Context) error)         { v.s.Go(fn) }
+func (v spawnerView) TryGo(fn func(ctx context.Context) error) bool { return v.s.TryGo(fn) }
+func (v spawnerView) Context() context.Context                      { return v.s.Context() }
+
+func (v spawnerView) SpawnChild(policy Policy, optFns ...Option) ChildSpawner {
+	return v.s.Child(policy, optFns...)
+}
+
+// scopeFromContext is FromContext returning the *Scope itself.
+func scopeFromContext(ctx context.Context) (*Scope, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	s, ok := ctx.Value(scopeKey{}).(*Scope)
+	return s, ok
+}
+
 // deriveContext applies deadline/timeout options consistently.
 //
 // Priority: explicit deadline, then timeout, then plain cancelable child context.
-// Nil parent is treated as context.Background().
-func deriveContext(parent context.Context, deadline time.Time, timeout time.Duration) (context.Context, context.CancelFunc) {
+// Nil parent is treated as context.Background(). The returned context records
+// a cancellation cause; an expired deadline or timeout is reported by
+// context.Cause as an error wrapping context.DeadlineExceeded.
+func deriveContext(parent context.Context, deadline time.Time, timeout time.Duration) (context.Context, context.CancelCauseFunc) {
 	if parent == nil {
 		parent = context.Background()
 	}
+	ctx, cancel := context.WithCancelCause(parent)
 	switch {
 	case !deadline.IsZero():
-		return context.WithDeadline(parent, deadline)
+		cause := fmt.Errorf("scope: deadline %s exceeded: %w", deadline.Format(time.RFC3339Nano), context.DeadlineExceeded)
+		dctx, stop := context.WithDeadlineCause(ctx, deadline, cause)
+		return dctx, stopBoth(cancel, stop)
 	case timeout > 0:
-		return context.WithTimeout(parent, timeout)
+		cause := fmt.Errorf("scope: timeout %v exceeded: %w", timeout, context.DeadlineExceeded)
+		dctx, stop := context.WithTimeoutCause(ctx, timeout, cause)
+		return dctx, stopBoth(cancel, stop)
+	default:
+		return ctx, cancel
+	}
+}
+
+// resolveDeadline combines o's deadline, timeout, and budget options into the
+// arguments for deriveContext. Budgets only apply when parent has a deadline;
+// when several limits apply, the earliest one wins.
+func resolveDeadline(parent context.Context, o Options) (time.Time, time.Duration) {
+	deadline, timeout := o.Deadline, o.Timeout
+	if parent == nil || (o.BudgetFraction <= 0 && o.BudgetReserve <= 0) {
+		return deadline, timeout
+	}
+	parentDeadline, ok := parent.Deadline()
+	if !ok {
+		return deadline, timeout
+	}
+	now := time.Now()
+	left := parentDeadline.Sub(now)
+	budget := left
+	if o.BudgetFraction > 0 && o.BudgetFraction < 1 {
+		budget = time.Duration(float64(left) * o.BudgetFraction)
+	}
+	if o.BudgetReserve > 0 {
+		budget = min(budget, left-o.BudgetReserve)
+	}
+	budgetDeadline := now.Add(budget)
+
+	switch {
+	case !deadline.IsZero():
+		if budgetDeadline.Before(deadline) {
+			deadline = budgetDeadline
+		}
+		return deadline, 0
+	case timeout > 0 && timeout <= budget:
+		return time.Time{}, timeout
 	default:
-		return context.WithCancel(parent)
+		return budgetDeadline, 0
+	}
+}
+
+// stopBoth cancels the cause-carrying context first so its cause wins, then
+// releases the deadline timer.
+func stopBoth(cancel context.CancelCauseFunc, stop context.CancelFunc) context.CancelCauseFunc {
+	return func(cause error) {
+		cancel(cause)
+		stop()
 	}
 }
@@ -2,6 +2,8 @@ package scope
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -9,7 +11,7 @@ import (
 func TestDeriveContextDeadlinePrecedence(t *testing.T) {
 	t.Parallel()
 	ctx, cancel := deriveContext(context.Background(), time.Now().Add(20*time.Millisecond), time.Second)
-	defer cancel()
+	defer cancel(nil)
 
 	deadline, ok := ctx.Deadline()
 	if !ok {
@@ -23,7 +25,7 @@ func TestDeriveContextDeadlinePrecedence(t *testing.T) {
 func TestDeriveContextUsesTimeout(t *testing.T) {
 	t.Parallel()
 	ctx, cancel := deriveContext(context.Background(), time.Time{}, 25*time.Millisecond)
-	defer cancel()
+	defer cancel(nil)
 	deadline, ok := ctx.Deadline()
 	if !ok {
 		t.Fatal("expected timeout to set deadline")
@@ -36,16 +38,142 @@ func TestDeriveContextUsesTimeout(t *testing.T) {
 func TestDeriveContextNilParent(t *testing.T) {
 	t.Parallel()
 	ctx, cancel := deriveContext(nil, time.Time{}, 0)
-	defer cancel()
+	defer cancel(nil)
 	select {
 	case <-ctx.Done():
 		t.Fatal("expected active context before cancel")
 	default:
 	}
-	cancel()
+	cancel(nil)
 	select {
 	case <-ctx.Done():
 	default:
 		t.Fatal("expected context to be canceled")
 	}
 }
+
+func TestDeriveContextTimeoutCause(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := deriveContext(context.Background(), time.Time{}, 5*time.Millisecond)
+	defer cancel(nil)
+	<-ctx.Done()
+	cause := context.Cause(ctx)
+	if !errors.Is(cause, context.DeadlineExceeded) {
+		t.Fatalf("expected cause wrapping DeadlineExceeded, got %v", cause)
+	}
+	if !strings.Contains(cause.Error(), "timeout") {
+		t.Fatalf("expected cause to describe the timeout, got %v", cause)
+	}
+}
+
+func TestDeriveContextCancelCause(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	ctx, cancel := deriveContext(context.Background(), time.Time{}, time.Second)
+	cancel(boom)
+	if got := context.Cause(ctx); !errors.Is(got, boom) {
+		t.Fatalf("expected cancel cause, got %v", got)
+	}
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", ctx.Err())
+	}
+}
+
+func TestResolveDeadlineBudget(t *testing.T) {
+	t.Parallel()
+	parent, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	deadline, timeout := resolveDeadline(parent, Options{BudgetFraction: 0.5})
+	if timeout != 0 {
+		t.Fatalf("budget should resolve to a deadline, got timeout %v", timeout)
+	}
+	if d := time.Until(deadline); d > 510*time.Millisecond || d < 400*time.Millisecond {
+		t.Fatalf("expected about half of the parent's budget, got %v", d)
+	}
+
+	deadline, _ = resolveDeadline(parent, Options{BudgetReserve: 300 * time.Millisecond})
+	if d := time.Until(deadline); d > 710*time.Millisecond || d < 600*time.Millisecond {
+		t.Fatalf("expected parent budget minus reserve, got %v", d)
+	}
+
+	deadline, timeout = resolveDeadline(parent, Options{BudgetFraction: 0.5, Timeout: 50 * time.Millisecond})
+	if !deadline.IsZero() || timeout != 50*time.Millisecond {
+		t.Fatalf("shorter timeout should win, got deadline=%v timeout=%v", deadline, timeout)
+	}
+
+	deadline, timeout = resolveDeadline(context.Background(), Options{BudgetFraction: 0.5})
+	if !deadline.IsZero() || timeout != 0 {
+		t.Fatalf("budget without parent deadline should be ignored, got deadline=%v timeout=%v", deadline, timeout)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+	if s, ok := FromContext(context.Background()); ok || s != nil {
+		t.Fatal("plain context should carry no scope")
+	}
+
+	parent := New(context.Background(), Supervisor)
+	if s, ok := FromContext(parent.Context()); !ok || s.Context() != parent.Context() {
+		t.Fatal("scope context should carry its scope")
+	}
+
+	nestedRan := make(chan struct{})
+	child := parent.Child(FailFast)
+	child.Go(func(ctx context.Context) error {
+		deep := context.WithValue(ctx, struct{}{}, "deep")
+		s, ok := FromContext(deep)
+		if !ok || s.Context() != child.Context() {
+			return errors.New("task context should carry the child scope")
+		}
+		s.Go(func(_ context.Context) error {
+			close(nestedRan)
+			return nil
+		})
+		return nil
+	})
+	if err := parent.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case <-nestedRan:
+	default:
+		t.Fatal("task spawned through FromContext should be joined by Wait")
+	}
+}
+
+func TestFromContextHidesWait(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast)
+	s.Go(func(ctx context.Context) error {
+		sp, _ := FromContext(ctx)
+		if _, ok := sp.(interface{ Wait() error }); ok {
+			return errors.New("FromContext exposed Wait of the enclosing scope")
+		}
+		if _, ok := sp.(*Scope); ok {
+			return errors.New("FromContext returned the *Scope itself")
+		}
+		c := sp.SpawnChild(Supervisor)
+		c.Go(func(context.Context) error { return nil })
+		return c.Wait()
+	})
+	if err := s.Wait(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestContextWithSpawnerShadowsScope(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast)
+	defer s.Wait()
+	fake := spawnerView{New(context.Background(), FailFast)}
+	defer fake.s.Wait()
+	ctx := ContextWithSpawner(s.Context(), fake)
+	if sp, ok := FromContext(ctx); !ok || sp != fake {
+		t.Fatalf("FromContext = %v, %v; want the attached Spawner", sp, ok)
+	}
+	if _, ok := scopeFromContext(ctx); ok {
+		t.Fatal("an attached Spawner should hide the enclosing *Scope")
+	}
+}
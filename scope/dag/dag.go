@@ -0,0 +1,247 @@
+// Package dag runs named tasks in dependency order inside a [scope.Scope].
+//
+// Each task starts as soon as all of its dependencies have succeeded, as a
+// task of the scope, so the scope's Limiter (WithMaxConcurrency), Observer and
+// Policy apply to it and a single Wait joins the whole graph. A task may read
+// values written by its dependencies without further synchronization.
+//
+// When a task fails or panics, its dependents do not run. Under
+// [scope.Supervisor] each of them is reported, once the scope is joined, as a
+// failure with a *SkippedError naming the task that failed; skipped tasks are
+// not started, so they take no Limiter slot and produce no Observer events.
+// Under [scope.FailFast] the first failure cancels the scope and the remaining
+// tasks are simply not started.
+package dag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/NetPo4ki/go-scope/scope"
+)
+
+// Sentinel errors returned by Add, Start and Run.
+var (
+	ErrDuplicate  = errors.New("dag: duplicate task")
+	ErrUnknownDep = errors.New("dag: unknown dependency")
+	ErrCycle      = errors.New("dag: dependency cycle")
+	// ErrSkipped matches every *SkippedError.
+	ErrSkipped = errors.New("dag: task skipped")
+)
+
+// SkippedError reports a task that did not run because a task it depends on,
+// directly or transitively, failed.
+type SkippedError struct {
+	// Task is the skipped task.
+	Task string
+	// Failed is the task whose failure caused the skip.
+	Failed string
+}
+
+func (e *SkippedError) Error() string {
+	return fmt.Sprintf("dag: %s skipped because %s failed", e.Task, e.Failed)
+}
+
+// Is reports whether target is ErrSkipped.
+func (e *SkippedError) Is(target error) bool { return target == ErrSkipped }
+
+// Graph is a set of named tasks and their dependencies. A Graph may be
+// started several times; each Start runs every task once.
+type Graph struct {
+	mu    sync.Mutex
+	nodes map[string]*node
+	order []*node // registration order
+}
+
+type node struct {
+	name string
+	fn   func(ctx context.Context) error
+	deps []string
+}
+
+// New returns an empty Graph.
+func New() *Graph {
+	return &Graph{nodes: make(map[string]*node)}
+}
+
+// Add registers a task that runs after every task named in deps has
+// succeeded. Dependencies may be added later; they are resolved by Start.
+func (g *Graph) Add(name string, fn func(ctx context.Context) error, deps ...string) error {
+	if fn == nil {
+		return scope.ErrNotAdmitted
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.nodes[name]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicate, name)
+	}
+	n := &node{name: name, fn: fn, deps: deps}
+	g.nodes[name] = n
+	g.order = append(g.order, n)
+	return nil
+}
+
+// Run starts the graph in a new Supervisor scope derived from ctx and waits
+// for it. Validation errors are returned before any task runs.
+func (g *Graph) Run(ctx context.Context, optFns ...scope.Option) error {
+	s := scope.New(ctx, scope.Supervisor, optFns...)
+	if err := g.Start(s); err != nil {
+		s.Cancel(err)
+		_ = s.Wait()
+		return err
+	}
+	return s.Wait()
+}
+
+// Start validates the graph and starts its tasks in s. It returns ErrCycle or
+// ErrUnknownDep without starting anything; otherwise the outcome is reported
+// by s.Wait.
+func (g *Graph) Start(s *scope.Scope) error {
+	g.mu.Lock()
+	r := &run{
+		s:          s,
+		nodes:      make(map[string]*node, len(g.nodes)),
+		pending:    make(map[string]int, len(g.nodes)),
+		dependents: make(map[string][]*node, len(g.nodes)),
+		skipped:    make(map[string]bool),
+	}
+	order := append([]*node(nil), g.order...)
+	for name, n := range g.nodes {
+		r.nodes[name] = n
+	}
+	g.mu.Unlock()
+
+	for _, n := range order {
+		for _, d := range n.deps {
+			if _, ok := r.nodes[d]; !ok {
+				return fmt.Errorf("%w: %q needs %q", ErrUnknownDep, n.name, d)
+			}
+			r.pending[n.name]++
+			r.dependents[d] = append(r.dependents[d], n)
+		}
+	}
+	if err := checkCycles(order, r.pending, r.dependents); err != nil {
+		return err
+	}
+
+	var roots []*node
+	for _, n := range order {
+		if r.pending[n.name] == 0 {
+			roots = append(roots, n)
+		}
+	}
+	for _, n := range roots {
+		r.start(n)
+	}
+	return nil
+}
+
+// checkCycles reports ErrCycle, naming the tasks on or behind a cycle, when
+// the graph cannot be ordered topologically.
+func checkCycles(order []*node, pending map[string]int, dependents map[string][]*node) error {
+	indeg := make(map[string]int, len(pending))
+	for k, v := range pending {
+		indeg[k] = v
+	}
+	var queue []*node
+	for _, n := range order {
+		if indeg[n.name] == 0 {
+			queue = append(queue, n)
+		}
+	}
+	seen := 0
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		seen++
+		for _, d := range dependents[n.name] {
+			if indeg[d.name]--; indeg[d.name] == 0 {
+				queue = append(queue, d)
+			}
+		}
+	}
+	if seen == len(order) {
+		return nil
+	}
+	var names []string
+	for _, n := range order {
+		if indeg[n.name] > 0 {
+			names = append(names, n.name)
+		}
+	}
+	return fmt.Errorf("%w among %q", ErrCycle, names)
+}
+
+// run tracks one execution of a Graph.
+type run struct {
+	s          *scope.Scope
+	nodes      map[string]*node
+	dependents map[string][]*node
+
+	mu      sync.Mutex
+	pending map[string]int // dependencies that have not succeeded yet
+	skipped map[string]bool
+}
+
+func (r *run) start(n *node) {
+	r.s.GoNamed(n.name, func(ctx context.Context) error {
+		defer func() {
+			// A panicking task fails its dependents too; the scope then
+			// handles the panic as usual.
+			if p := recover(); p != nil {
+				r.finish(n, errPanicked)
+				panic(p)
+			}
+		}()
+		err := n.fn(ctx)
+		r.finish(n, err)
+		return err
+	})
+}
+
+// errPanicked stands in for the error of a task that panicked.
+var errPanicked = errors.New("dag: task panicked")
+
+// finish starts the dependents of n that became ready, or skips every task
+// that depends on n if it failed.
+func (r *run) finish(n *node, err error) {
+	var ready, skip []*node
+	r.mu.Lock()
+	if err == nil {
+		for _, d := range r.dependents[n.name] {
+			if r.pending[d.name]--; r.pending[d.name] == 0 && !r.skipped[d.name] {
+				ready = append(ready, d)
+			}
+		}
+	} else {
+		queue := append([]*node(nil), r.dependents[n.name]...)
+		for len(queue) > 0 {
+			d := queue[0]
+			queue = queue[1:]
+			if r.skipped[d.name] {
+				continue
+			}
+			r.skipped[d.name] = true
+			skip = append(skip, d)
+			queue = append(queue, r.dependents[d.name]...)
+		}
+	}
+	r.mu.Unlock()
+
+	if len(skip) > 0 {
+		// Report the skips once every task has finished, so that they follow
+		// the failure that caused them: under FailFast that failure cancels
+		// the scope and the skips are dropped.
+		r.s.Defer(func(context.Context) error {
+			for _, d := range skip {
+				r.s.Report(d.name, &SkippedError{Task: d.name, Failed: n.name})
+			}
+			return nil
+		})
+	}
+	for _, d := range ready {
+		r.start(d)
+	}
+}
@@ -0,0 +1,200 @@
+package dag
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NetPo4ki/go-scope/scope"
+)
+
+func TestRunRespectsDependencies(t *testing.T) {
+	t.Parallel()
+	g := New()
+	var mu sync.Mutex
+	finished := map[string]bool{}
+	task := func(name string, deps ...string) {
+		_ = g.Add(name, func(context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, d := range deps {
+				if !finished[d] {
+					t.Errorf("%s started before %s finished", name, d)
+				}
+			}
+			finished[name] = true
+			return nil
+		}, deps...)
+	}
+	task("link", "compile-a", "compile-b")
+	task("compile-a", "fetch")
+	task("compile-b", "fetch")
+	task("fetch")
+	task("test", "link")
+	if err := g.Run(context.Background()); err != nil {
+		t.Fatalf("Run() = %v", err)
+	}
+	if len(finished) != 5 {
+		t.Fatalf("finished %v, want all 5 tasks", finished)
+	}
+}
+
+func TestRunWithinMaxConcurrency(t *testing.T) {
+	t.Parallel()
+	g := New()
+	var active, peak atomic.Int32
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		_ = g.Add(name, func(context.Context) error {
+			n := active.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			active.Add(-1)
+			return nil
+		})
+	}
+	if err := g.Run(context.Background(), scope.WithMaxConcurrency(2)); err != nil {
+		t.Fatalf("Run() = %v", err)
+	}
+	if p := peak.Load(); p > 2 {
+		t.Fatalf("peak concurrency = %d, want <= 2", p)
+	}
+}
+
+func TestRunSkipsDependentsOfFailedTask(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	g := New()
+	var ranIndependent atomic.Bool
+	_ = g.Add("fetch", func(context.Context) error { return boom })
+	_ = g.Add("build", func(context.Context) error { t.Error("build ran"); return nil }, "fetch")
+	_ = g.Add("test", func(context.Context) error { t.Error("test ran"); return nil }, "build")
+	_ = g.Add("lint", func(context.Context) error { ranIndependent.Store(true); return nil })
+
+	err := g.Run(context.Background())
+	var se *scope.ScopeError
+	if !errors.As(err, &se) || se.Count() != 3 {
+		t.Fatalf("Run() = %v, want 3 failures", err)
+	}
+	if !ranIndependent.Load() {
+		t.Fatal("independent task did not run")
+	}
+	for _, f := range se.Failures() {
+		switch f.Name {
+		case "fetch":
+			if !errors.Is(f.Err, boom) {
+				t.Fatalf("fetch failure = %v", f.Err)
+			}
+		case "build", "test":
+			var skip *SkippedError
+			if !errors.As(f.Err, &skip) || skip.Failed != "fetch" || !errors.Is(f.Err, ErrSkipped) {
+				t.Fatalf("%s failure = %v, want skipped because fetch failed", f.Name, f.Err)
+			}
+		default:
+			t.Fatalf("unexpected failure %q", f.Name)
+		}
+	}
+}
+
+func TestRunSkipsDependentsOfPanickingTask(t *testing.T) {
+	t.Parallel()
+	g := New()
+	_ = g.Add("fetch", func(context.Context) error { panic("fetch blew up") })
+	_ = g.Add("build", func(context.Context) error { t.Error("build ran"); return nil }, "fetch")
+
+	err := g.Run(context.Background())
+	var se *scope.ScopeError
+	if !errors.As(err, &se) || se.Count() != 2 {
+		t.Fatalf("Run() = %v, want 2 failures", err)
+	}
+	for _, f := range se.Failures() {
+		switch f.Name {
+		case "fetch":
+			var pe *scope.PanicError
+			if !errors.As(f.Err, &pe) || pe.Value() != "fetch blew up" {
+				t.Fatalf("fetch failure = %v, want its panic", f.Err)
+			}
+		case "build":
+			var skip *SkippedError
+			if !errors.As(f.Err, &skip) || skip.Failed != "fetch" {
+				t.Fatalf("build failure = %v, want skipped because fetch failed", f.Err)
+			}
+		default:
+			t.Fatalf("unexpected failure %q", f.Name)
+		}
+	}
+}
+
+func TestSkippedTasksBypassLimiterAndObserver(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	g := New()
+	_ = g.Add("a", func(context.Context) error { return boom })
+	_ = g.Add("b", func(context.Context) error { return nil }, "a")
+	_ = g.Add("c", func(context.Context) error { return nil }, "b")
+	obs := &startCounter{}
+	s := scope.New(context.Background(), scope.Supervisor, scope.WithObserver(obs))
+	if err := g.Start(s); err != nil {
+		t.Fatal(err)
+	}
+	var se *scope.ScopeError
+	if err := s.Wait(); !errors.As(err, &se) || se.Count() != 3 {
+		t.Fatalf("Wait() = %v, want 3 failures", err)
+	}
+	if n := obs.started.Load(); n != 1 {
+		t.Fatalf("observer saw %d task starts, want only the task that ran", n)
+	}
+}
+
+// startCounter counts TaskStarted events.
+type startCounter struct {
+	scope.NopObserver
+	started atomic.Int32
+}
+
+func (o *startCounter) TaskStarted(context.Context) { o.started.Add(1) }
+
+func TestStartFailFastStopsGraph(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	g := New()
+	_ = g.Add("a", func(context.Context) error { return boom })
+	_ = g.Add("b", func(context.Context) error { t.Error("b ran"); return nil }, "a")
+	s := scope.New(context.Background(), scope.FailFast)
+	if err := g.Start(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Wait(); !errors.Is(err, boom) {
+		t.Fatalf("Wait() = %v, want boom", err)
+	}
+}
+
+func TestStartValidatesGraph(t *testing.T) {
+	t.Parallel()
+	noop := func(context.Context) error { t.Error("task ran"); return nil }
+
+	g := New()
+	_ = g.Add("a", noop, "c")
+	_ = g.Add("b", noop, "a")
+	_ = g.Add("c", noop, "b")
+	_ = g.Add("d", noop)
+	if err := g.Run(context.Background()); !errors.Is(err, ErrCycle) {
+		t.Fatalf("Run() = %v, want ErrCycle", err)
+	}
+
+	g = New()
+	_ = g.Add("a", noop, "missing")
+	if err := g.Run(context.Background()); !errors.Is(err, ErrUnknownDep) {
+		t.Fatalf("Run() = %v, want ErrUnknownDep", err)
+	}
+	if err := g.Add("a", noop); !errors.Is(err, ErrDuplicate) {
+		t.Fatalf("Add() = %v, want ErrDuplicate", err)
+	}
+}
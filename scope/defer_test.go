@@ -0,0 +1,111 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDeferRunsLIFOAfterTasks(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	cleanupErr := errors.New("cleanup failed")
+	s := New(context.Background(), FailFast, WithCleanupTimeout(time.Second))
+
+	taskDone := make(chan struct{})
+	var order []string
+	s.Defer(func(ctx context.Context) error {
+		order = append(order, "first")
+		if ctx.Err() != nil {
+			return errors.New("cleanup context should not be canceled")
+		}
+		if _, ok := ctx.Deadline(); !ok {
+			return errors.New("cleanup context should carry the cleanup timeout")
+		}
+		return nil
+	})
+	s.Defer(func(_ context.Context) error {
+		select {
+		case <-taskDone:
+		default:
+			return errors.New("hook ran before tasks finished")
+		}
+		order = append(order, "second")
+		return cleanupErr
+	})
+	s.Go(func(ctx context.Context) error {
+		defer close(taskDone)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	s.Go(func(_ context.Context) error { return boom })
+
+	err := s.Wait()
+	if !errors.Is(err, boom) || !errors.Is(err, cleanupErr) {
+		t.Fatalf("expected task and cleanup errors, got %v", err)
+	}
+	if len(order) != 2 || order[0] != "second" || order[1] != "first" {
+		t.Fatalf("hooks should run in LIFO order, got %v", order)
+	}
+}
+
+func TestDeferAfterWaitRunsImmediately(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), Supervisor)
+	if err := s.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	ran := false
+	s.Defer(func(_ context.Context) error {
+		ran = true
+		return errors.New("ignored")
+	})
+	if !ran {
+		t.Fatal("Defer on a joined scope should run immediately")
+	}
+	if err := s.Wait(); err != nil {
+		t.Fatalf("late hook error should not change Wait's result, got %v", err)
+	}
+}
+
+func TestDeferRecoversPanickingHook(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast)
+	ran := false
+	s.Defer(func(context.Context) error {
+		ran = true
+		return nil
+	})
+	s.Defer(func(context.Context) error { panic("cleanup blew up") })
+
+	waited := make(chan error, 1)
+	s.Go(func(context.Context) error {
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+	go func() { waited <- s.Wait() }()
+	time.Sleep(time.Millisecond)
+
+	err := s.Wait()
+	var pe *PanicError
+	if !errors.As(err, &pe) || pe.Value() != "cleanup blew up" {
+		t.Fatalf("Wait() = %v, want the hook's PanicError", err)
+	}
+	if !ran {
+		t.Fatal("hooks after a panicking hook should still run")
+	}
+	select {
+	case <-s.Done():
+	default:
+		t.Fatal("Done should be closed after Wait returns")
+	}
+	select {
+	case other := <-waited:
+		if other != err {
+			t.Fatalf("concurrent Wait() = %v, want %v", other, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("concurrent Wait hung after a panicking hook")
+	}
+}
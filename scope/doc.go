@@ -6,8 +6,14 @@
 //   - Spawn with Go/TryGo while the scope is active.
 //   - Join exactly where ownership should end with Wait.
 //   - Cancel is idempotent and records the first non-nil cause.
-//   - After Cancel or once Wait has started, the scope stops accepting new
-//     tasks. Go becomes a no-op, while TryGo reports false.
+//   - After Cancel, or once its context is canceled, the scope stops
+//     accepting new tasks. Once Wait has started, tasks are accepted only
+//     while owned tasks are still running; after that the scope is closed. A
+//     refused Go is a no-op, while TryGo reports false and TryGoErr returns
+//     ErrScopeCanceled or ErrScopeClosed. GoAfter, GoAt, GoEvery, GoKeyed,
+//     GoCloser, NewBatcher and Do refuse work with the same errors.
+//   - Wait may be called from several goroutines; all calls return the same
+//     result. Done, State, and Err observe completion without blocking.
 //   - Parent scopes own child scopes; parent Wait blocks until children finish.
 package scope
 
@@ -1,23 +1,216 @@
 package scope
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"runtime"
 	"runtime/debug"
+	"strings"
+	"time"
 )
 
-// panicError preserves the panic value and stack trace for diagnostics.
-type panicError struct {
+var (
+	// ErrScopeClosed is returned by TryGoErr when Wait has already started or
+	// completed on the scope.
+	ErrScopeClosed = errors.New("scope: closed")
+	// ErrScopeCanceled is returned by TryGoErr when the scope has been canceled.
+	ErrScopeCanceled = errors.New("scope: canceled")
+	// ErrNotAdmitted reports a task that never started: fn was nil, or the
+	// scope's limiter did not admit it before the scope was canceled. Limiter
+	// refusals are recorded in Wait's error wrapped with the limiter's error.
+	ErrNotAdmitted = errors.New("scope: task not admitted")
+)
+
+// TaskFailure describes one failed task of a Supervisor scope.
+type TaskFailure struct {
+	// Name is the task name given to GoNamed, or "" for unnamed tasks.
+	Name string
+	// Index is the spawn order of the task within its scope, starting at 0.
+	// Child scopes are counted as tasks of their parent.
+	Index int
+	// Err is the error returned by the task or recovered from its panic.
+	Err error
+	// Duration is how long the task ran before failing.
+	Duration time.Duration
+	// Panicked reports whether the task panicked.
+	Panicked bool
+	// Attempt is the 1-based attempt number that produced Err.
+	Attempt int
+	// Collateral reports that the task only failed because its scope was
+	// already canceled: Err matches the scope context's error, or Err is a
+	// child ScopeError made only of collateral failures.
+	Collateral bool
+}
+
+// ScopeError is returned by Wait on a Supervisor scope when one or more tasks
+// failed. It unwraps to the individual task errors, so errors.Is and
+// errors.As behave as with errors.Join.
+type ScopeError struct {
+	failures []TaskFailure
+}
+
+// Error lists the root causes. Collateral failures are summarized in a final
+// line unless every failure is collateral, in which case all are listed.
+func (e *ScopeError) Error() string {
+	list := e.RootCauses()
+	if len(list) == 0 {
+		list = e.failures
+	}
+	var b strings.Builder
+	for i, f := range list {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(f.Err.Error())
+	}
+	if n := len(e.failures) - len(list); n > 0 {
+		fmt.Fprintf(&b, "\n(%d collateral failures omitted)", n)
+	}
+	return b.String()
+}
+
+// Unwrap returns the errors of all failed tasks.
+func (e *ScopeError) Unwrap() []error {
+	errs := make([]error, len(e.failures))
+	for i, f := range e.failures {
+		errs[i] = f.Err
+	}
+	return errs
+}
+
+// Failures returns the failed tasks in the order their failures were recorded.
+func (e *ScopeError) Failures() []TaskFailure {
+	out := make([]TaskFailure, len(e.failures))
+	copy(out, e.failures)
+	return out
+}
+
+// Count returns the number of failed tasks.
+func (e *ScopeError) Count() int { return len(e.failures) }
+
+// RootCauses returns the failures that are not collateral.
+func (e *ScopeError) RootCauses() []TaskFailure {
+	return selectFailures(e.failures, false)
+}
+
+// Collateral returns the failures of tasks that only failed because the scope
+// was canceled.
+func (e *ScopeError) Collateral() []TaskFailure {
+	return selectFailures(e.failures, true)
+}
+
+func (e *ScopeError) allCollateral() bool {
+	for _, f := range e.failures {
+		if !f.Collateral {
+			return false
+		}
+	}
+	return true
+}
+
+func selectFailures(failures []TaskFailure, collateral bool) []TaskFailure {
+	var out []TaskFailure
+	for _, f := range failures {
+		if f.Collateral == collateral {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// isCollateral reports whether err is a consequence of ctx being canceled
+// rather than an independent failure.
+func isCollateral(ctx context.Context, err error) bool {
+	if se, ok := err.(*ScopeError); ok {
+		return se.allCollateral()
+	}
+	cerr := ctx.Err()
+	return cerr != nil && errors.Is(err, cerr)
+}
+
+// Filter returns the failures whose error matches target according to errors.Is.
+func (e *ScopeError) Filter(target error) []TaskFailure {
+	var out []TaskFailure
+	for _, f := range e.failures {
+		if errors.Is(f.Err, target) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// maxPanicFrames bounds the number of program counters captured for a panic.
+const maxPanicFrames = 64
+
+// Frame is a single call site from the stack of a panicking task.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// PanicError preserves the value and stack of a panic recovered from a task.
+// Use errors.As to retrieve it from Wait's error.
+type PanicError struct {
 	value any
 	stack []byte
+	pcs   []uintptr
+	task  string
 }
 
-func (e *panicError) Error() string {
+// Value returns the original value passed to panic.
+func (e *PanicError) Value() any { return e.value }
+
+// Stack returns the raw stack trace captured when the panic was recovered,
+// as formatted by runtime/debug.Stack.
+func (e *PanicError) Stack() []byte { return e.stack }
+
+// Task returns the name of the panicking task, or "" when it was unnamed.
+func (e *PanicError) Task() string { return e.task }
+
+// Frames returns the stack of the panicking goroutine, innermost call first.
+// Frames belonging to the panic and recovery machinery are omitted. It
+// returns nil when no stack was captured.
+func (e *PanicError) Frames() []Frame {
+	if len(e.pcs) == 0 {
+		return nil
+	}
+	var all []Frame
+	frames := runtime.CallersFrames(e.pcs)
+	for more := true; more; {
+		var f runtime.Frame
+		f, more = frames.Next()
+		if f.Function == "runtime.gopanic" {
+			all = all[:0]
+			continue
+		}
+		all = append(all, Frame{Function: f.Function, File: f.File, Line: f.Line})
+	}
+	return all
+}
+
+func (e *PanicError) Error() string {
+	if e.task != "" {
+		return fmt.Sprintf("panic in task %q: %v\n%s", e.task, e.value, e.stack)
+	}
 	return fmt.Sprintf("panic: %v\n%s", e.value, e.stack)
 }
 
-func panicToError(v any) error {
-	return &panicError{
+// Unwrap returns the panic value when it is an error, so errors.Is and
+// errors.As see through the panic.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.value.(error)
+	return err
+}
+
+func panicToError(v any, task string) *PanicError {
+	pcs := make([]uintptr, maxPanicFrames)
+	n := runtime.Callers(2, pcs)
+	return &PanicError{
 		value: v,
 		stack: debug.Stack(),
+		pcs:   pcs[:n],
+		task:  task,
 	}
 }
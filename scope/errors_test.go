@@ -0,0 +1,168 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func panickingTask(_ context.Context) error {
+	panic("frame-value")
+}
+
+func TestPanicErrorExposesValueAndFrames(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast)
+	s.GoNamed("loader", panickingTask)
+	err := s.Wait()
+
+	var pe *PanicError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected *PanicError, got %T: %v", err, err)
+	}
+	if pe.Value() != "frame-value" {
+		t.Fatalf("unexpected panic value: %v", pe.Value())
+	}
+	if pe.Task() != "loader" {
+		t.Fatalf("unexpected task name: %q", pe.Task())
+	}
+	if !strings.Contains(string(pe.Stack()), "goroutine") {
+		t.Fatalf("expected raw stack, got %s", pe.Stack())
+	}
+	frames := pe.Frames()
+	if len(frames) == 0 {
+		t.Fatal("expected parsed frames")
+	}
+	if !strings.HasSuffix(frames[0].Function, ".panickingTask") {
+		t.Fatalf("innermost frame should be the panicking function, got %+v", frames[0])
+	}
+	if !strings.HasSuffix(frames[0].File, "errors_test.go") || frames[0].Line == 0 {
+		t.Fatalf("unexpected file/line in frame: %+v", frames[0])
+	}
+}
+
+func TestPanicErrorFramesWithoutStack(t *testing.T) {
+	t.Parallel()
+	pe := &PanicError{value: "no stack"}
+	if frames := pe.Frames(); frames != nil {
+		t.Fatalf("expected nil frames without a captured stack, got %+v", frames)
+	}
+}
+
+func TestPanicErrorUnwrapsErrorValue(t *testing.T) {
+	t.Parallel()
+	sentinel := errors.New("sentinel")
+	s := New(context.Background(), FailFast)
+	s.Go(func(_ context.Context) error {
+		panic(sentinel)
+	})
+	err := s.Wait()
+	if !errors.Is(err, sentinel) {
+		t.Fatalf("expected errors.Is to see panic value, got %v", err)
+	}
+	var pe *PanicError
+	if !errors.As(err, &pe) || pe.Task() != "" {
+		t.Fatalf("expected unnamed *PanicError, got %v", err)
+	}
+}
+
+func TestScopeErrorAttributesFailures(t *testing.T) {
+	t.Parallel()
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	s := New(context.Background(), Supervisor)
+	s.Go(func(_ context.Context) error { return nil })
+	s.GoNamed("a", func(_ context.Context) error { return errA })
+	s.GoNamed("b", func(_ context.Context) error {
+		time.Sleep(10 * time.Millisecond)
+		return errB
+	})
+	s.GoNamed("crash", func(_ context.Context) error { panic("boom") })
+
+	err := s.Wait()
+	var se *ScopeError
+	if !errors.As(err, &se) {
+		t.Fatalf("expected *ScopeError, got %T: %v", err, err)
+	}
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("ScopeError should unwrap to task errors, got %v", err)
+	}
+	if se.Count() != 3 {
+		t.Fatalf("expected 3 failures, got %d", se.Count())
+	}
+
+	byName := map[string]TaskFailure{}
+	for _, f := range se.Failures() {
+		byName[f.Name] = f
+	}
+	if f := byName["a"]; f.Index != 1 || f.Attempt != 1 || f.Panicked {
+		t.Fatalf("unexpected failure for a: %+v", f)
+	}
+	if f := byName["b"]; f.Index != 2 || f.Duration < 10*time.Millisecond {
+		t.Fatalf("unexpected failure for b: %+v", f)
+	}
+	if f := byName["crash"]; !f.Panicked || f.Index != 3 {
+		t.Fatalf("unexpected failure for crash: %+v", f)
+	}
+
+	if got := se.Filter(errB); len(got) != 1 || got[0].Name != "b" {
+		t.Fatalf("Filter(errB) = %+v", got)
+	}
+}
+
+func TestScopeErrorSeparatesCollateral(t *testing.T) {
+	t.Parallel()
+	root := errors.New("root")
+	s := New(context.Background(), Supervisor)
+	s.GoNamed("root", func(_ context.Context) error { return root })
+	for i := 0; i < 2; i++ {
+		s.Go(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}
+	time.Sleep(10 * time.Millisecond)
+	s.Cancel(nil)
+
+	var se *ScopeError
+	if err := s.Wait(); !errors.As(err, &se) {
+		t.Fatalf("expected *ScopeError, got %v", err)
+	}
+	if got := se.RootCauses(); len(got) != 1 || got[0].Name != "root" {
+		t.Fatalf("unexpected root causes: %+v", got)
+	}
+	if got := se.Collateral(); len(got) != 2 {
+		t.Fatalf("expected 2 collateral failures, got %+v", got)
+	}
+	if msg := se.Error(); !strings.HasPrefix(msg, "root\n") || !strings.Contains(msg, "2 collateral") {
+		t.Fatalf("unexpected error message: %q", msg)
+	}
+}
+
+func TestDropCollateral(t *testing.T) {
+	t.Parallel()
+	stop := errors.New("stop")
+	parent := New(context.Background(), Supervisor, WithDropCollateral(true))
+	child := parent.Child(Supervisor)
+	child.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	parent.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	time.Sleep(10 * time.Millisecond)
+	parent.Cancel(stop)
+
+	err := parent.Wait()
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected cancellation cause when all failures are collateral, got %v", err)
+	}
+	var se *ScopeError
+	if errors.As(err, &se) {
+		t.Fatalf("collateral failures should be dropped, got %v", se.Failures())
+	}
+}
@@ -0,0 +1,144 @@
+package scope
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// Overlap controls what GoEvery does when a run is due while the previous
+// run is still in progress.
+type Overlap int
+
+const (
+	// OverlapSkip drops runs that are due while the previous run is active.
+	OverlapSkip Overlap = iota
+	// OverlapQueue remembers at most one due run and starts it as soon as
+	// the previous run returns.
+	OverlapQueue
+)
+
+// EveryOption configures GoEvery.
+type EveryOption func(*everyOptions)
+
+type everyOptions struct {
+	name      string
+	delay     time.Duration
+	jitter    time.Duration
+	overlap   Overlap
+	maxErrors int
+}
+
+// EveryName sets the task name reported for every run.
+func EveryName(name string) EveryOption { return func(o *everyOptions) { o.name = name } }
+
+// EveryInitialDelay delays the first run by d; by default it starts at once.
+func EveryInitialDelay(d time.Duration) EveryOption { return func(o *everyOptions) { o.delay = d } }
+
+// EveryJitter adds a random delay in [0, d) to every scheduled run.
+func EveryJitter(d time.Duration) EveryOption { return func(o *everyOptions) { o.jitter = d } }
+
+// EveryOverlap sets the Overlap policy (OverlapSkip by default).
+func EveryOverlap(p Overlap) EveryOption { return func(o *everyOptions) { o.overlap = p } }
+
+// EveryMaxErrors sets how many consecutive runs must fail before the last
+// error is reported to the scope Policy (n>0, default 1). The count resets
+// after a successful run and after each report.
+func EveryMaxErrors(n int) EveryOption { return func(o *everyOptions) { o.maxErrors = n } }
+
+// GoEvery runs fn every interval until the scope is canceled.
+//
+// Each run is a separate task: it is admitted by the scope's Limiter,
+// reported to the Observer, and recovered like a task started with Go. Runs
+// never overlap; see EveryOverlap. Failed runs are counted and only reported
+// to the Policy after EveryMaxErrors consecutive failures, with
+// TaskFailure.Attempt set to that count. Under WithPanicAsError a panicking
+// run counts as a failed run.
+//
+// The schedule counts toward Wait, so Wait returns only after the scope has
+// been canceled; the timer is stopped as soon as that happens. An interval
+// <= 0 is refused with ErrNotAdmitted.
+func (s *Scope) GoEvery(interval time.Duration, fn func(ctx context.Context) error, optFns ...EveryOption) error {
+	if fn == nil || interval <= 0 {
+		return ErrNotAdmitted
+	}
+	o := everyOptions{maxErrors: 1}
+	for _, f := range optFns {
+		if f != nil {
+			f(&o)
+		}
+	}
+	if o.maxErrors < 1 {
+		o.maxErrors = 1
+	}
+	s.mu.Lock()
+	if err := s.reserveLocked(); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	s.mu.Unlock()
+	go s.every(interval, fn, o)
+	return nil
+}
+
+// every drives a GoEvery schedule until the scope is canceled.
+func (s *Scope) every(interval time.Duration, fn func(ctx context.Context) error, o everyOptions) {
+	defer s.taskDone()
+	jitter := func() time.Duration {
+		if o.jitter <= 0 {
+			return 0
+		}
+		return rand.N(o.jitter)
+	}
+
+	// failures is only touched by report. Runs never overlap, and finished is
+	// signaled from the after hook, once report has returned, so each report
+	// happens before the next run is spawned.
+	failures := 0
+	report := func(err error) (error, int) {
+		if err == nil {
+			failures = 0
+			return nil, 0
+		}
+		failures++
+		if failures < o.maxErrors {
+			return nil, 0
+		}
+		n := failures
+		failures = 0
+		return err, n
+	}
+	finished := make(chan struct{}, 1)
+	run := taskSpec{
+		name:   o.name,
+		fn:     fn,
+		report: report,
+		after:  func() { finished <- struct{}{} },
+	}
+
+	timer := time.NewTimer(o.delay + jitter())
+	defer timer.Stop()
+	running, queued := false, false
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-timer.C:
+			timer.Reset(interval + jitter())
+			if running {
+				queued = o.overlap == OverlapQueue
+				continue
+			}
+		case <-finished:
+			running = false
+			if !queued {
+				continue
+			}
+			queued = false
+		}
+		if s.spawnTask(run) != nil {
+			return
+		}
+		running = true
+	}
+}
@@ -0,0 +1,180 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGoEveryRunsUntilCancel(t *testing.T) {
+	t.Parallel()
+	obs := &countObserver{}
+	s := New(context.Background(), FailFast, WithObserver(obs))
+	var runs atomic.Int32
+	if err := s.GoEvery(2*time.Millisecond, func(context.Context) error {
+		if runs.Add(1) == 5 {
+			s.Cancel(nil)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v, want nil", err)
+	}
+	n := runs.Load()
+	if n != 5 {
+		t.Fatalf("runs = %d, want 5", n)
+	}
+	if obs.started.Load() != int64(n) || obs.finished.Load() != int64(n) {
+		t.Fatalf("observer saw %d/%d tasks, want %d", obs.started.Load(), obs.finished.Load(), n)
+	}
+	if err := s.GoEvery(time.Millisecond, func(context.Context) error { return nil }); !errors.Is(err, ErrScopeCanceled) {
+		t.Fatalf("GoEvery after cancel = %v", err)
+	}
+}
+
+func TestGoEveryConsecutiveErrorLimit(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	s := New(context.Background(), Supervisor)
+	var runs atomic.Int32
+	_ = s.GoEvery(time.Millisecond, func(context.Context) error {
+		switch n := runs.Add(1); {
+		case n == 2: // resets the count
+			return nil
+		case n == 6:
+			s.Cancel(nil)
+			return nil
+		default:
+			return boom
+		}
+	}, EveryMaxErrors(3), EveryName("poll"))
+	err := s.Wait()
+	var se *ScopeError
+	if !errors.As(err, &se) || se.Count() != 1 {
+		t.Fatalf("Wait() = %v, want one reported failure", err)
+	}
+	f := se.Failures()[0]
+	if f.Name != "poll" || f.Attempt != 3 || !errors.Is(f.Err, boom) {
+		t.Fatalf("failure = %+v, want poll after 3 consecutive errors", f)
+	}
+}
+
+func TestGoEveryOverlap(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name    string
+		overlap Overlap
+		want    int32
+	}{
+		{"skip", OverlapSkip, 1},
+		{"queue", OverlapQueue, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := New(context.Background(), FailFast)
+			var runs, active atomic.Int32
+			var overlapped atomic.Bool
+			release := make(chan struct{})
+			_ = s.GoEvery(100*time.Millisecond, func(ctx context.Context) error {
+				if active.Add(1) > 1 {
+					overlapped.Store(true)
+				}
+				defer active.Add(-1)
+				if runs.Add(1) == 1 {
+					<-release // the tick at 100ms is due meanwhile
+				}
+				return nil
+			}, EveryOverlap(tc.overlap))
+			time.Sleep(150 * time.Millisecond)
+			close(release)
+			// The queued run starts at once; the next tick is due at 200ms.
+			time.Sleep(20 * time.Millisecond)
+			got := runs.Load()
+			s.Cancel(nil)
+			_ = s.Wait()
+			if overlapped.Load() {
+				t.Fatal("runs overlapped")
+			}
+			if got != tc.want {
+				t.Fatalf("runs after release = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGoEveryInitialDelay(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast)
+	var runs atomic.Int32
+	_ = s.GoEvery(time.Millisecond, func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}, EveryInitialDelay(time.Hour), EveryJitter(time.Millisecond))
+	time.Sleep(10 * time.Millisecond)
+	s.Cancel(nil)
+	_ = s.Wait()
+	if runs.Load() != 0 {
+		t.Fatalf("runs = %d before the initial delay", runs.Load())
+	}
+}
+
+func TestGoEveryErrorCountRace(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	s := New(context.Background(), Supervisor)
+	var runs atomic.Int32
+	_ = s.GoEvery(10*time.Microsecond, func(context.Context) error {
+		if runs.Add(1) == 200 {
+			s.Cancel(nil)
+		}
+		return boom
+	}, EveryOverlap(OverlapQueue), EveryMaxErrors(3))
+	err := s.Wait()
+	var se *ScopeError
+	if !errors.As(err, &se) {
+		t.Fatalf("Wait() = %v, want ScopeError", err)
+	}
+	for _, f := range se.Failures() {
+		if f.Attempt != 3 {
+			t.Fatalf("failure reported after %d errors, want 3", f.Attempt)
+		}
+	}
+}
+
+func TestGoEveryCountsPanics(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), Supervisor, WithPanicAsError(true))
+	var runs atomic.Int32
+	_ = s.GoEvery(time.Millisecond, func(context.Context) error {
+		if runs.Add(1) == 2 {
+			s.Cancel(nil)
+			return nil
+		}
+		panic("flaky")
+	}, EveryMaxErrors(2))
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v, want a single panic below EveryMaxErrors to be tolerated", err)
+	}
+
+	s = New(context.Background(), Supervisor, WithPanicAsError(true))
+	runs.Store(0)
+	_ = s.GoEvery(time.Millisecond, func(context.Context) error {
+		if runs.Add(1) == 3 {
+			s.Cancel(nil)
+			return nil
+		}
+		panic("flaky")
+	}, EveryMaxErrors(2))
+	err := s.Wait()
+	var se *ScopeError
+	if !errors.As(err, &se) || se.Count() != 1 {
+		t.Fatalf("Wait() = %v, want one failure", err)
+	}
+	if f := se.Failures()[0]; !f.Panicked || f.Attempt != 2 {
+		t.Fatalf("failure = %+v, want a panic reported after 2 failed runs", f)
+	}
+}
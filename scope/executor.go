@@ -0,0 +1,69 @@
+package scope
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/NetPo4ki/go-scope/scope/internal"
+)
+
+// Executor runs the body of each task spawned by a Scope. Execute must
+// arrange for fn to run exactly once; it may run it synchronously.
+type Executor interface {
+	Execute(fn func())
+}
+
+type goroutineExecutor struct{}
+
+func (goroutineExecutor) Execute(fn func()) { go fn() }
+
+// GoroutineExecutor runs every task on a fresh goroutine. It is the default.
+func GoroutineExecutor() Executor { return goroutineExecutor{} }
+
+type inlineExecutor struct{}
+
+func (inlineExecutor) Execute(fn func()) { fn() }
+
+// InlineExecutor runs every task synchronously inside Go/TryGo. It is meant
+// for deterministic tests: tasks that block on siblings will deadlock. Tasks
+// started by GoAfter or GoAt run on the goroutine that fires the scope's
+// timers, so a running one delays every other delayed task of the scope.
+func InlineExecutor() Executor { return inlineExecutor{} }
+
+type lockedThreadExecutor struct{}
+
+func (lockedThreadExecutor) Execute(fn func()) {
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		fn()
+	}()
+}
+
+// LockedThreadExecutor runs every task on a fresh goroutine locked to its OS
+// thread with runtime.LockOSThread, for cgo or other thread-affine work.
+func LockedThreadExecutor() Executor { return lockedThreadExecutor{} }
+
+// PoolExecutor runs tasks on reusable worker goroutines, which avoids
+// repeated goroutine creation and stack growth for short, frequent tasks.
+// A PoolExecutor may be shared by many scopes; call Close when done with it.
+type PoolExecutor struct {
+	pool *internal.WorkerPool
+}
+
+// NewPoolExecutor returns a PoolExecutor with at most size workers (size > 0).
+// Idle workers exit after idleTimeout; idleTimeout <= 0 keeps them until
+// Close. When all workers are busy, tasks run on fresh goroutines.
+func NewPoolExecutor(size int, idleTimeout time.Duration) *PoolExecutor {
+	if size <= 0 {
+		size = 1
+	}
+	return &PoolExecutor{pool: internal.NewWorkerPool(size, idleTimeout)}
+}
+
+// Execute implements Executor.
+func (e *PoolExecutor) Execute(fn func()) { e.pool.Submit(fn) }
+
+// Close stops idle workers and waits for busy workers to finish their
+// current task. Tasks executed after Close run on fresh goroutines.
+func (e *PoolExecutor) Close() { e.pool.Close() }
@@ -0,0 +1,40 @@
+//go:build linux
+
+package scope
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestLockedThreadExecutor(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast, WithExecutor(LockedThreadExecutor()))
+	s.Go(func(_ context.Context) error {
+		// Each helper locks whichever thread it lands on and parks there, so
+		// a task that is not locked would be moved to another thread.
+		release := make(chan struct{})
+		defer close(release)
+		tid := syscall.Gettid()
+		for i := 0; i < 20; i++ {
+			go func() {
+				runtime.LockOSThread()
+				defer runtime.UnlockOSThread()
+				<-release
+			}()
+			runtime.Gosched()
+			time.Sleep(time.Millisecond)
+			if got := syscall.Gettid(); got != tid {
+				return fmt.Errorf("task moved from thread %d to %d", tid, got)
+			}
+		}
+		return nil
+	})
+	if err := s.Wait(); err != nil {
+		t.Fatal(err)
+	}
+}
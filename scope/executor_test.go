@@ -0,0 +1,94 @@
+package scope
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	id, _ := strconv.ParseUint(string(buf[:bytes.IndexByte(buf, ' ')]), 10, 64)
+	return id
+}
+
+func TestInlineExecutorRunsSynchronously(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), Supervisor, WithExecutor(InlineExecutor()))
+	caller := goroutineID()
+	var ran atomic.Bool
+	s.Go(func(_ context.Context) error {
+		if goroutineID() != caller {
+			return errors.New("inline task ran on another goroutine")
+		}
+		ran.Store(true)
+		return nil
+	})
+	if !ran.Load() {
+		t.Fatal("inline task should have completed inside Go")
+	}
+	if err := s.Wait(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPoolExecutorReusesWorkers(t *testing.T) {
+	t.Parallel()
+	pool := NewPoolExecutor(1, 0)
+	defer pool.Close()
+
+	s := New(context.Background(), FailFast, WithExecutor(pool))
+	ids := make(chan uint64, 2)
+	record := func(_ context.Context) error {
+		ids <- goroutineID()
+		return nil
+	}
+	s.Go(record)
+	first := <-ids
+	time.Sleep(5 * time.Millisecond) // let the worker go idle
+	s.Go(record)
+	second := <-ids
+	if err := s.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Fatalf("expected the idle worker to be reused, got goroutines %d and %d", first, second)
+	}
+}
+
+func TestPoolExecutorOverflowAndChild(t *testing.T) {
+	t.Parallel()
+	pool := NewPoolExecutor(2, time.Millisecond)
+	defer pool.Close()
+
+	s := New(context.Background(), Supervisor, WithExecutor(pool))
+	child := s.Child(Supervisor)
+	var wg sync.WaitGroup
+	wg.Add(8)
+	release := make(chan struct{})
+	var ran atomic.Int32
+	for i := 0; i < 8; i++ {
+		child.Go(func(_ context.Context) error {
+			wg.Done()
+			<-release
+			ran.Add(1)
+			return nil
+		})
+	}
+	wg.Wait() // all tasks run concurrently despite only two workers
+	close(release)
+	if err := s.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if ran.Load() != 8 {
+		t.Fatalf("expected 8 tasks, got %d", ran.Load())
+	}
+}
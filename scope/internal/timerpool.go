@@ -1 +1,106 @@
 package internal
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// TimerQueue runs functions at given times using a single runtime timer for
+// any number of pending entries. No goroutine is kept while entries wait; due
+// functions run sequentially on the timer's goroutine, so they should only
+// hand work off.
+type TimerQueue struct {
+	mu      sync.Mutex
+	entries timerHeap
+	timer   *time.Timer
+	seq     uint64
+	stopped bool
+}
+
+type timerEntry struct {
+	at  time.Time
+	seq uint64 // keeps FIFO order for equal times
+	fn  func()
+}
+
+// Add schedules fn to run at at, or as soon as possible if at has passed. It
+// reports false, without scheduling fn, once the queue has been stopped.
+func (q *TimerQueue) Add(at time.Time, fn func()) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.stopped {
+		return false
+	}
+	q.seq++
+	heap.Push(&q.entries, timerEntry{at: at, seq: q.seq, fn: fn})
+	if q.entries[0].seq == q.seq {
+		q.armLocked()
+	}
+	return true
+}
+
+// Stop drops every pending entry and returns how many were dropped. Later
+// calls to Add report false.
+func (q *TimerQueue) Stop() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.stopped = true
+	if q.timer != nil {
+		q.timer.Stop()
+	}
+	n := len(q.entries)
+	q.entries = nil
+	return n
+}
+
+// Len reports the number of pending entries.
+func (q *TimerQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.entries)
+}
+
+// armLocked points the timer at the earliest entry. It requires q.mu.
+func (q *TimerQueue) armLocked() {
+	d := time.Until(q.entries[0].at)
+	if q.timer == nil {
+		q.timer = time.AfterFunc(d, q.fire)
+		return
+	}
+	q.timer.Reset(d)
+}
+
+func (q *TimerQueue) fire() {
+	q.mu.Lock()
+	var due []func()
+	now := time.Now()
+	for len(q.entries) > 0 && !q.entries[0].at.After(now) {
+		due = append(due, heap.Pop(&q.entries).(timerEntry).fn)
+	}
+	if len(q.entries) > 0 && !q.stopped {
+		q.armLocked()
+	}
+	q.mu.Unlock()
+	for _, fn := range due {
+		fn()
+	}
+}
+
+type timerHeap []timerEntry
+
+func (h timerHeap) Len() int { return len(h) }
+func (h timerHeap) Less(i, j int) bool {
+	if h[i].at.Equal(h[j].at) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].at.Before(h[j].at)
+}
+func (h timerHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *timerHeap) Push(x any)   { *h = append(*h, x.(timerEntry)) }
+func (h *timerHeap) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"sync"
+	"time"
+)
+
+// WorkerPool runs functions on a bounded set of reusable goroutines. Workers
+// are started on demand and exit after staying idle for the idle timeout.
+// When every worker is busy, Submit falls back to a fresh goroutine rather
+// than blocking, so tasks that submit more tasks cannot deadlock the pool.
+type WorkerPool struct {
+	work   chan func()
+	slots  chan struct{}
+	idle   time.Duration
+	mu     sync.Mutex
+	closed bool
+	quit   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewWorkerPool returns a pool of at most size workers (size must be > 0).
+// idle <= 0 keeps workers alive until Close.
+func NewWorkerPool(size int, idle time.Duration) *WorkerPool {
+	return &WorkerPool{
+		work:  make(chan func()),
+		slots: make(chan struct{}, size),
+		idle:  idle,
+		quit:  make(chan struct{}),
+	}
+}
+
+// Submit runs fn on an idle worker, a new worker, or a fresh goroutine, in
+// that order of preference.
+func (p *WorkerPool) Submit(fn func()) {
+	select {
+	case p.work <- fn:
+		return
+	default:
+	}
+	p.mu.Lock()
+	if !p.closed {
+		select {
+		case p.slots <- struct{}{}:
+			p.wg.Add(1)
+			p.mu.Unlock()
+			go p.worker(fn)
+			return
+		default:
+		}
+	}
+	p.mu.Unlock()
+	go fn()
+}
+
+// Close stops idle workers and waits for busy ones to finish their current
+// function. Submit keeps working after Close, using fresh goroutines.
+func (p *WorkerPool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.quit)
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+func (p *WorkerPool) worker(fn func()) {
+	defer func() {
+		<-p.slots
+		p.wg.Done()
+	}()
+	var timer *time.Timer
+	var timeout <-chan time.Time
+	if p.idle > 0 {
+		timer = time.NewTimer(p.idle)
+		defer timer.Stop()
+	}
+	for {
+		fn()
+		if timer != nil {
+			timer.Reset(p.idle)
+			timeout = timer.C
+		}
+		select {
+		case fn = <-p.work:
+		case <-timeout:
+			return
+		case <-p.quit:
+			return
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+)
+
+// keyQueue holds the tasks of one GoKeyed key that have not started yet.
+type keyQueue struct {
+	pending []func(ctx context.Context) error
+	running bool
+}
+
+// GoKeyed starts fn as a task of the scope after every task previously
+// submitted with the same key has finished. Tasks with different keys run
+// concurrently, subject to the scope's Limiter. key must be comparable; its
+// fmt.Sprint form is used as the task name.
+//
+// A failed task follows the Policy: under FailFast the scope is canceled and
+// the tasks still queued for any key are dropped, while under Supervisor the
+// failure is recorded and the next task for the key starts. Queued tasks count
+// toward Wait.
+func (s *Scope) GoKeyed(key any, fn func(ctx context.Context) error) error {
+	if fn == nil {
+		return ErrNotAdmitted
+	}
+	s.mu.Lock()
+	if err := s.reserveLocked(); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	if s.keyed == nil {
+		s.keyed = make(map[any]*keyQueue)
+	}
+	q := s.keyed[key]
+	if q == nil {
+		q = &keyQueue{}
+		s.keyed[key] = q
+	}
+	q.pending = append(q.pending, fn)
+	start := !q.running
+	q.running = true
+	s.mu.Unlock()
+	if start {
+		s.runKeyed(key, q)
+	}
+	return nil
+}
+
+// runKeyed starts the next queued task for key, if any. Each queued task
+// holds a slot counted by Wait, which passes to the spawned task.
+func (s *Scope) runKeyed(key any, q *keyQueue) {
+	s.mu.Lock()
+	if len(q.pending) == 0 {
+		q.running = false
+		delete(s.keyed, key)
+		s.mu.Unlock()
+		return
+	}
+	fn := q.pending[0]
+	q.pending[0] = nil
+	q.pending = q.pending[1:]
+	s.mu.Unlock()
+
+	err := s.spawnTask(taskSpec{
+		name:  fmt.Sprint(key),
+		fn:    fn,
+		after: func() { s.runKeyed(key, q) },
+	})
+	if err == nil {
+		s.taskDone()
+		return
+	}
+	// The scope was canceled: drop this task and the rest of the queue.
+	s.mu.Lock()
+	dropped := len(q.pending) + 1
+	q.pending = nil
+	q.running = false
+	delete(s.keyed, key)
+	s.mu.Unlock()
+	for i := 0; i < dropped; i++ {
+		s.taskDone()
+	}
+}
@@ -0,0 +1,102 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGoKeyedOrdersPerKey(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast, WithMaxConcurrency(4))
+	var mu sync.Mutex
+	seen := map[string][]int{}
+	var active, peak atomic.Int32
+	for i := 0; i < 20; i++ {
+		for _, key := range []string{"a", "b", "c"} {
+			if err := s.GoKeyed(key, func(context.Context) error {
+				n := active.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(100 * time.Microsecond)
+				active.Add(-1)
+				mu.Lock()
+				seen[key] = append(seen[key], i)
+				mu.Unlock()
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v", err)
+	}
+	for key, order := range seen {
+		if len(order) != 20 {
+			t.Fatalf("key %s ran %d tasks, want 20", key, len(order))
+		}
+		for i, v := range order {
+			if v != i {
+				t.Fatalf("key %s ran out of order: %v", key, order)
+			}
+		}
+	}
+	if p := peak.Load(); p > 3 {
+		t.Fatalf("peak concurrency = %d, want at most one task per key", p)
+	}
+}
+
+func TestGoKeyedFailFastDropsQueued(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	s := New(context.Background(), FailFast)
+	var ran atomic.Int32
+	_ = s.GoKeyed(1, func(context.Context) error { return boom })
+	for i := 0; i < 10; i++ {
+		_ = s.GoKeyed(1, func(context.Context) error {
+			ran.Add(1)
+			return nil
+		})
+	}
+	if err := s.Wait(); !errors.Is(err, boom) {
+		t.Fatalf("Wait() = %v, want boom", err)
+	}
+	if ran.Load() != 0 {
+		t.Fatalf("%d queued tasks ran after the key failed", ran.Load())
+	}
+	if err := s.GoKeyed(1, func(context.Context) error { return nil }); !errors.Is(err, ErrScopeCanceled) {
+		t.Fatalf("GoKeyed after cancel = %v, want ErrScopeCanceled", err)
+	}
+}
+
+func TestGoKeyedSupervisorContinuesKey(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	s := New(context.Background(), Supervisor)
+	var order []int
+	for i := 0; i < 3; i++ {
+		_ = s.GoKeyed("k", func(context.Context) error {
+			order = append(order, i) // serialized by the key
+			if i == 1 {
+				return boom
+			}
+			return nil
+		})
+	}
+	err := s.Wait()
+	var se *ScopeError
+	if !errors.As(err, &se) || se.Count() != 1 || se.Failures()[0].Name != "k" {
+		t.Fatalf("Wait() = %v, want one failure named k", err)
+	}
+	if len(order) != 3 || order[0] != 0 || order[1] != 1 || order[2] != 2 {
+		t.Fatalf("order = %v, want [0 1 2]", order)
+	}
+}
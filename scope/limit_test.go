@@ -2,6 +2,7 @@ package scope
 
 import (
 	"context"
+	"errors"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -116,3 +117,32 @@ func TestChildMaxConcurrencyBound(t *testing.T) {
 	_ = child.Wait()
 	_ = parent.Wait()
 }
+
+func TestLimiterRefusalReportedAsNotAdmitted(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), Supervisor, WithMaxConcurrency(1))
+	started := make(chan struct{})
+	s.Go(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return nil
+	})
+	<-started
+	ran := atomic.Bool{}
+	s.Go(func(_ context.Context) error {
+		ran.Store(true)
+		return nil
+	})
+	time.Sleep(10 * time.Millisecond)
+	s.Cancel(nil)
+	err := s.Wait()
+	if !errors.Is(err, ErrNotAdmitted) {
+		t.Fatalf("expected ErrNotAdmitted in Wait error, got %v", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected limiter cause in Wait error, got %v", err)
+	}
+	if ran.Load() {
+		t.Fatal("refused task should not run")
+	}
+}
@@ -10,7 +10,7 @@ type NopObserver struct{}
 
 func (NopObserver) ScopeCreated(context.Context) {}
 
-func (NopObserver) ScopeCancelled(context.Context, error) {}
+func (NopObserver) ScopeCancelled(context.Context, CancelReason, error) {}
 
 func (NopObserver) ScopeJoined(context.Context, time.Duration) {}
 
@@ -47,9 +47,9 @@ func (c *chainedObserver) ScopeCreated(ctx context.Context) {
 	}
 }
 
-func (c *chainedObserver) ScopeCancelled(ctx context.Context, cause error) {
+func (c *chainedObserver) ScopeCancelled(ctx context.Context, reason CancelReason, cause error) {
 	for _, o := range c.observers {
-		o.ScopeCancelled(ctx, cause)
+		o.ScopeCancelled(ctx, reason, cause)
 	}
 }
 
@@ -17,7 +17,7 @@ type recObserver struct {
 
 func (o *recObserver) ScopeCreated(context.Context) { o.created++ }
 
-func (o *recObserver) ScopeCancelled(context.Context, error) { o.cancelled++ }
+func (o *recObserver) ScopeCancelled(context.Context, CancelReason, error) { o.cancelled++ }
 
 func (o *recObserver) ScopeJoined(context.Context, time.Duration) { o.joined++ }
 
@@ -40,7 +40,7 @@ func TestChainObserversForwardsEvents(t *testing.T) {
 
 	ctx := context.Background()
 	obs.ScopeCreated(ctx)
-	obs.ScopeCancelled(ctx, errors.New("x"))
+	obs.ScopeCancelled(ctx, ReasonManual, errors.New("x"))
 	obs.ScopeJoined(ctx, time.Millisecond)
 	obs.TaskStarted(ctx)
 	obs.TaskFinished(ctx, time.Millisecond, nil, false)
@@ -0,0 +1,223 @@
+// Package proc runs external commands as tasks owned by a [scope.Scope].
+//
+// Each command is started in its own process group. When the scope is
+// canceled, the whole group receives SIGTERM and, if it has not exited after
+// a grace period, SIGKILL. The task error reports the exit status and the
+// tail of the command's stderr. On platforms without process groups only the
+// direct child process is killed.
+package proc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NetPo4ki/go-scope/scope"
+)
+
+// Default settings used when no Option overrides them.
+const (
+	DefaultGracePeriod = 5 * time.Second
+	DefaultStderrTail  = 4 << 10
+)
+
+// Option configures how a command is run.
+type Option func(*options)
+
+type options struct {
+	grace      time.Duration
+	stderrTail int
+}
+
+// WithGracePeriod sets how long to wait after SIGTERM before sending SIGKILL.
+func WithGracePeriod(d time.Duration) Option { return func(o *options) { o.grace = d } }
+
+// WithStderrTail sets how many trailing bytes of stderr are kept for the
+// error (n <= 0 disables capture).
+func WithStderrTail(n int) Option { return func(o *options) { o.stderrTail = n } }
+
+// ExitError reports a command that failed to run, exited unsuccessfully, or
+// was stopped because its scope was canceled.
+type ExitError struct {
+	// Args is the command line, as in exec.Cmd.Args.
+	Args []string
+	// ExitCode is the process exit code, or -1 if it was killed by a signal
+	// or never started.
+	ExitCode int
+	// Signal is the signal that terminated the process, or nil.
+	Signal os.Signal
+	// Stderr holds the last bytes the command wrote to stderr.
+	Stderr string
+	// Err is the underlying error from exec.Cmd.
+	Err error
+	// Cause is the scope's cancellation cause when the command was stopped
+	// because of cancellation, otherwise nil.
+	Cause error
+}
+
+func (e *ExitError) Error() string {
+	var b strings.Builder
+	b.WriteString("proc: ")
+	b.WriteString(commandName(e.Args))
+	switch {
+	case e.Signal != nil && e.Cause != nil:
+		fmt.Fprintf(&b, " killed by %v after cancellation: %v", e.Signal, e.Cause)
+	case e.Signal != nil:
+		fmt.Fprintf(&b, " killed by %v", e.Signal)
+	case e.ExitCode >= 0:
+		fmt.Fprintf(&b, " exited with status %d", e.ExitCode)
+	default:
+		fmt.Fprintf(&b, ": %v", e.Err)
+	}
+	if e.Stderr != "" {
+		b.WriteString(": ")
+		b.WriteString(strings.TrimSpace(e.Stderr))
+	}
+	return b.String()
+}
+
+// Unwrap returns the exec error and, after cancellation, the cancellation
+// cause, so errors.Is(err, context.Canceled) holds for canceled commands.
+func (e *ExitError) Unwrap() []error {
+	if e.Cause != nil {
+		return []error{e.Err, e.Cause}
+	}
+	return []error{e.Err}
+}
+
+// Go runs cmd as a task of s and returns the spawn error from
+// [scope.Scope.TryGoErr]. The task fails with an *ExitError when the command
+// cannot start or does not exit successfully.
+func Go(s *scope.Scope, cmd *exec.Cmd, opts ...Option) error {
+	return s.TryGoErr(func(ctx context.Context) error {
+		return Run(ctx, cmd, opts...)
+	})
+}
+
+// Run starts cmd in a new process group and waits for it. When ctx is done
+// before the command exits, the group is sent SIGTERM and, after the grace
+// period, SIGKILL, even when the leader itself has already exited on
+// SIGTERM; Run returns once the whole group is gone. Run must not be used with a cmd created by
+// exec.CommandContext, whose own kill would bypass the escalation.
+//
+// Unless cmd.WaitDelay is already set, it is set to the grace period, so a
+// background process that inherited the command's stderr or stdout pipe
+// cannot keep Run waiting after the command itself has exited. Such a
+// command still succeeds when its own exit status is zero.
+func Run(ctx context.Context, cmd *exec.Cmd, opts ...Option) error {
+	o := options{grace: DefaultGracePeriod, stderrTail: DefaultStderrTail}
+	for _, fn := range opts {
+		fn(&o)
+	}
+
+	var tail *tailBuffer
+	if o.stderrTail > 0 {
+		tail = &tailBuffer{max: o.stderrTail}
+		if cmd.Stderr != nil {
+			cmd.Stderr = io.MultiWriter(cmd.Stderr, tail)
+		} else {
+			cmd.Stderr = tail
+		}
+	}
+	setProcessGroup(cmd)
+	if cmd.WaitDelay == 0 {
+		cmd.WaitDelay = o.grace
+	}
+
+	if err := cmd.Start(); err != nil {
+		return &ExitError{Args: cmd.Args, ExitCode: -1, Err: err}
+	}
+	waited := make(chan error, 1)
+	go func() { waited <- cmd.Wait() }()
+
+	var err, cause error
+	select {
+	case err = <-waited:
+	case <-ctx.Done():
+		cause = context.Cause(ctx)
+		terminate(cmd)
+		grace := time.NewTimer(o.grace)
+		select {
+		case err = <-waited:
+			// The leader is gone, but members of its group may ignore
+			// SIGTERM; they get SIGKILL once the grace period is over.
+			if !drainGroup(cmd, grace.C) {
+				kill(cmd)
+			}
+		case <-grace.C:
+			kill(cmd)
+			err = <-waited
+		}
+		grace.Stop()
+	}
+	if err == nil || (cause == nil && errors.Is(err, exec.ErrWaitDelay)) {
+		return nil
+	}
+
+	ee := &ExitError{Args: cmd.Args, ExitCode: -1, Err: err, Cause: cause}
+	var xe *exec.ExitError
+	if errors.As(err, &xe) {
+		ee.ExitCode = xe.ExitCode()
+		ee.Signal = exitSignal(xe)
+	}
+	if tail != nil {
+		ee.Stderr = tail.String()
+	}
+	return ee
+}
+
+// drainGroup waits until the rest of cmd's process group has exited or
+// expired fires. It reports whether the group exited in time.
+func drainGroup(cmd *exec.Cmd, expired <-chan time.Time) bool {
+	tick := time.NewTicker(10 * time.Millisecond)
+	defer tick.Stop()
+	for groupAlive(cmd) {
+		select {
+		case <-tick.C:
+		case <-expired:
+			return false
+		}
+	}
+	return true
+}
+
+func commandName(args []string) string {
+	if len(args) == 0 {
+		return "command"
+	}
+	return args[0]
+}
+
+// tailBuffer keeps the last max bytes written to it.
+type tailBuffer struct {
+	mu  sync.Mutex
+	max int
+	buf []byte
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := len(p)
+	if n >= t.max {
+		t.buf = append(t.buf[:0], p[n-t.max:]...)
+		return n, nil
+	}
+	if over := len(t.buf) + n - t.max; over > 0 {
+		t.buf = append(t.buf[:0], t.buf[over:]...)
+	}
+	t.buf = append(t.buf, p...)
+	return n, nil
+}
+
+func (t *tailBuffer) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return string(t.buf)
+}
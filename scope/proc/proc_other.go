@@ -0,0 +1,24 @@
+//go:build !unix
+
+package proc
+
+import (
+	"os"
+	"os/exec"
+)
+
+func setProcessGroup(*exec.Cmd) {}
+
+// terminate kills the direct child: there is no portable SIGTERM.
+func terminate(cmd *exec.Cmd) { kill(cmd) }
+
+func kill(cmd *exec.Cmd) {
+	if cmd.Process != nil {
+		_ = cmd.Process.Kill()
+	}
+}
+
+// groupAlive reports false: only the direct child is tracked.
+func groupAlive(*exec.Cmd) bool { return false }
+
+func exitSignal(*exec.ExitError) os.Signal { return nil }
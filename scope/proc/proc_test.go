@@ -0,0 +1,200 @@
+//go:build unix
+
+package proc
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/NetPo4ki/go-scope/scope"
+)
+
+func writeScript(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "script.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunSuccess(t *testing.T) {
+	t.Parallel()
+	s := scope.New(context.Background(), scope.FailFast)
+	if err := Go(s, exec.Command(writeScript(t, "exit 0"))); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRunExitStatusAndStderrTail(t *testing.T) {
+	t.Parallel()
+	script := writeScript(t, "echo 'first line' >&2\necho 'last words' >&2\nexit 3")
+	err := Run(context.Background(), exec.Command(script), WithStderrTail(11))
+
+	var ee *ExitError
+	if !errors.As(err, &ee) {
+		t.Fatalf("expected *ExitError, got %T: %v", err, err)
+	}
+	if ee.ExitCode != 3 || ee.Signal != nil || ee.Cause != nil {
+		t.Fatalf("unexpected exit error: %+v", ee)
+	}
+	if ee.Stderr != "last words\n" {
+		t.Fatalf("expected stderr tail, got %q", ee.Stderr)
+	}
+	if !strings.Contains(err.Error(), "exited with status 3: last words") {
+		t.Fatalf("unexpected message: %v", err)
+	}
+}
+
+func TestCancelSendsSIGTERM(t *testing.T) {
+	t.Parallel()
+	ready := filepath.Join(t.TempDir(), "ready")
+	script := writeScript(t, "trap 'echo terminated >&2; exit 143' TERM\ntouch "+ready+"\nwhile :; do sleep 0.01; done")
+	// Supervisor keeps the task's own error in Wait's result after Cancel.
+	s := scope.New(context.Background(), scope.Supervisor)
+	stop := errors.New("stop")
+	if err := Go(s, exec.Command(script), WithGracePeriod(5*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	waitForFile(t, ready)
+	start := time.Now()
+	s.Cancel(stop)
+	err := s.Wait()
+	if time.Since(start) > 2*time.Second {
+		t.Fatal("graceful termination should not wait for the grace period")
+	}
+	var ee *ExitError
+	if !errors.As(err, &ee) {
+		t.Fatalf("expected *ExitError, got %v", err)
+	}
+	if !errors.Is(ee, stop) || ee.ExitCode != 143 {
+		t.Fatalf("expected exit after SIGTERM with cancel cause, got %+v", ee)
+	}
+	if !strings.Contains(ee.Stderr, "terminated") {
+		t.Fatalf("script should have seen SIGTERM, stderr=%q", ee.Stderr)
+	}
+}
+
+func TestCancelEscalatesToSIGKILLForGroup(t *testing.T) {
+	t.Parallel()
+	ready := filepath.Join(t.TempDir(), "ready")
+	// Ignore SIGTERM in the script and in its child so only SIGKILL can stop them.
+	script := writeScript(t, "trap '' TERM\nsleep 30 &\ntouch "+ready+"\nwait")
+	s := scope.New(context.Background(), scope.Supervisor)
+	if err := Go(s, exec.Command(script), WithGracePeriod(50*time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	waitForFile(t, ready)
+	start := time.Now()
+	s.Cancel(nil)
+	err := s.Wait()
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("SIGKILL escalation did not stop the process group")
+	}
+	var ee *ExitError
+	if !errors.As(err, &ee) {
+		t.Fatalf("expected *ExitError, got %v", err)
+	}
+	if ee.Signal != syscall.SIGKILL {
+		t.Fatalf("expected SIGKILL, got %+v", ee)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled command should match context.Canceled, got %v", err)
+	}
+}
+
+func TestCancelKillsGroupAfterLeaderExits(t *testing.T) {
+	t.Parallel()
+	ready := filepath.Join(t.TempDir(), "ready")
+	pidFile := filepath.Join(t.TempDir(), "pid")
+	// The leader exits on SIGTERM; its background child ignores it.
+	script := writeScript(t, "sh -c 'trap \"\" TERM; echo $$ > "+pidFile+"; while :; do sleep 0.01; done' >/dev/null 2>&1 &\n"+
+		"trap 'exit 143' TERM\nwhile [ ! -s "+pidFile+" ]; do sleep 0.01; done\ntouch "+ready+"\nwait")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- Run(ctx, exec.Command(script), WithGracePeriod(100*time.Millisecond)) }()
+	waitForFile(t, ready)
+	raw, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(raw)))
+	if err != nil {
+		t.Fatalf("bad child pid %q: %v", raw, err)
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the grace period")
+	}
+	// Kill(pid, 0) also succeeds for an unreaped zombie, so check its state.
+	deadline := time.Now().Add(2 * time.Second)
+	for processRunning(pid) {
+		if time.Now().After(deadline) {
+			_ = syscall.Kill(pid, syscall.SIGKILL)
+			t.Fatal("child ignoring SIGTERM survived the grace period")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRunDoesNotWaitForBackgroundPipeHolder(t *testing.T) {
+	t.Parallel()
+	cmd := exec.Command("sh", "-c", "sleep 10 &")
+	start := time.Now()
+	err := Run(context.Background(), cmd, WithGracePeriod(50*time.Millisecond))
+	defer func() { _ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL) }()
+	if err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("Run waited for the background process holding stderr")
+	}
+}
+
+func TestGoRejectedByClosedScope(t *testing.T) {
+	t.Parallel()
+	s := scope.New(context.Background(), scope.FailFast)
+	s.Cancel(nil)
+	if err := Go(s, exec.Command("true")); !errors.Is(err, scope.ErrScopeCanceled) {
+		t.Fatalf("expected ErrScopeCanceled, got %v", err)
+	}
+	_ = s.Wait()
+}
+
+func waitForFile(t *testing.T, path string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", path)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// processRunning reports whether pid exists and is not a zombie.
+func processRunning(pid int) bool {
+	stat, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return syscall.Kill(pid, 0) == nil
+	}
+	// The state follows the parenthesized command name.
+	fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+	return len(fields) > 0 && fields[0] != "Z"
+}
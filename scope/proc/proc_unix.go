@@ -0,0 +1,43 @@
+//go:build unix
+
+package proc
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+	cmd.SysProcAttr.Pgid = 0
+}
+
+// terminate sends SIGTERM to the command's process group.
+func terminate(cmd *exec.Cmd) { signalGroup(cmd, syscall.SIGTERM) }
+
+// kill sends SIGKILL to the command's process group.
+func kill(cmd *exec.Cmd) { signalGroup(cmd, syscall.SIGKILL) }
+
+// groupAlive reports whether any process of the command's group is left.
+func groupAlive(cmd *exec.Cmd) bool {
+	return cmd.Process != nil && syscall.Kill(-cmd.Process.Pid, 0) == nil
+}
+
+func signalGroup(cmd *exec.Cmd, sig syscall.Signal) {
+	if cmd.Process == nil {
+		return
+	}
+	// A negative pid addresses the whole process group led by the child.
+	_ = syscall.Kill(-cmd.Process.Pid, sig)
+}
+
+func exitSignal(xe *exec.ExitError) os.Signal {
+	if ws, ok := xe.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		return ws.Signal()
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package scope
+
+// CancelReason classifies why a Scope was canceled.
+type CancelReason int
+
+const (
+	// ReasonNone means the scope has not been canceled.
+	ReasonNone CancelReason = iota
+	// ReasonManual means Cancel was called.
+	ReasonManual
+	// ReasonSiblingFailure means a task or child scope failed under FailFast,
+	// or a task panicked under WithRepanic.
+	ReasonSiblingFailure
+	// ReasonDeadline means the scope's own deadline or timeout expired.
+	ReasonDeadline
+	// ReasonParent means the parent context or parent scope was canceled.
+	ReasonParent
+	// ReasonShutdown means Shutdown was called.
+	ReasonShutdown
+)
+
+// String returns a short snake_case name suitable for metric labels.
+func (r CancelReason) String() string {
+	switch r {
+	case ReasonNone:
+		return "none"
+	case ReasonManual:
+		return "manual"
+	case ReasonSiblingFailure:
+		return "sibling_failure"
+	case ReasonDeadline:
+		return "deadline"
+	case ReasonParent:
+		return "parent"
+	case ReasonShutdown:
+		return "shutdown"
+	default:
+		return "unknown"
+	}
+}
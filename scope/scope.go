@@ -3,9 +3,12 @@ package scope
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/NetPo4ki/go-scope/scope/internal"
 )
 
 // Policy controls error propagation behavior in a Scope.
@@ -25,6 +28,9 @@ type Option func(*Options)
 type Options struct {
 	// PanicAsError converts a panic inside a task to an error when true.
 	PanicAsError bool
+	// Repanic captures a task panic, cancels the scope, and re-panics it from
+	// Wait in the caller's goroutine. It takes precedence over PanicAsError.
+	Repanic bool
 	// Observer receives lifecycle events; if nil, hooks are skipped (near-zero overhead).
 	Observer Observer
 	// MaxConcurrency bounds concurrent tasks in a scope when > 0.
@@ -33,6 +39,22 @@ type Options struct {
 	Timeout time.Duration
 	// Deadline applies an absolute deadline to the scope when non-zero.
 	Deadline time.Time
+	// BudgetFraction, when in (0, 1], limits the scope to that fraction of the
+	// time the parent context has left before its deadline.
+	BudgetFraction float64
+	// BudgetReserve, when > 0, ends the scope that long before the parent
+	// context's deadline, leaving the parent time for its own work.
+	BudgetReserve time.Duration
+	// ShutdownTimeout, when > 0, cancels the scope with Shutdown that long
+	// after RequestShutdown unless it has been joined by then.
+	ShutdownTimeout time.Duration
+	// CleanupTimeout bounds the context passed to Defer hooks when > 0.
+	CleanupTimeout time.Duration
+	// Executor runs task bodies; nil means a fresh goroutine per task.
+	Executor Executor
+	// DropCollateral omits collateral failures from a Supervisor scope's
+	// ScopeError, keeping only the root causes.
+	DropCollateral bool
 }
 
 func defaultOptions() Options { return Options{PanicAsError: true} }
@@ -40,6 +62,39 @@ func defaultOptions() Options { return Options{PanicAsError: true} }
 // WithPanicAsError toggles converting task panics into errors.
 func WithPanicAsError(v bool) Option { return func(o *Options) { o.PanicAsError = v } }
 
+// WithRepanic toggles re-panicking task panics from Wait instead of crashing
+// the task goroutine or converting them to errors.
+func WithRepanic(v bool) Option { return func(o *Options) { o.Repanic = v } }
+
+// WithBudgetFraction gives the scope fraction f (0 < f <= 1) of the time its
+// parent has left before the parent's deadline. It has no effect when the
+// parent has no deadline. Combined with WithTimeout or WithDeadline, the
+// earliest deadline wins. Like other options it is inherited by child scopes,
+// so each level takes its share of what its parent has left.
+func WithBudgetFraction(f float64) Option { return func(o *Options) { o.BudgetFraction = f } }
+
+// WithBudgetReserve ends the scope d before its parent's deadline. It has no
+// effect when the parent has no deadline. Combined with WithBudgetFraction,
+// WithTimeout, or WithDeadline, the earliest deadline wins.
+func WithBudgetReserve(d time.Duration) Option { return func(o *Options) { o.BudgetReserve = d } }
+
+// WithShutdownTimeout bounds the graceful phase started by RequestShutdown,
+// including the first signal of NewFromSignals (d>0).
+func WithShutdownTimeout(d time.Duration) Option { return func(o *Options) { o.ShutdownTimeout = d } }
+
+// WithCleanupTimeout bounds the context passed to Defer hooks (d>0).
+func WithCleanupTimeout(d time.Duration) Option { return func(o *Options) { o.CleanupTimeout = d } }
+
+// WithExecutor selects how task bodies are run (nil = fresh goroutine per task).
+// Child scopes inherit the executor; their join bookkeeping always uses a
+// separate goroutine so that InlineExecutor cannot block Child.
+func WithExecutor(e Executor) Option { return func(o *Options) { o.Executor = e } }
+
+// WithDropCollateral toggles omitting collateral failures (tasks that only
+// failed because the scope was canceled) from a Supervisor scope's Wait error.
+// When every failure is collateral, Wait returns the cancellation cause instead.
+func WithDropCollateral(v bool) Option { return func(o *Options) { o.DropCollateral = v } }
+
 // WithObserver attaches an observer for metrics/tracing hooks (nil = disabled).
 func WithObserver(obs Observer) Option { return func(o *Options) { o.Observer = obs } }
 
@@ -55,7 +110,7 @@ func WithDeadline(t time.Time) Option { return func(o *Options) { o.Deadline = t
 // Observer receives lifecycle events for metrics/tracing.
 type Observer interface {
 	ScopeCreated(ctx context.Context)
-	ScopeCancelled(ctx context.Context, cause error)
+	ScopeCancelled(ctx context.Context, reason CancelReason, cause error)
 	ScopeJoined(ctx context.Context, wait time.Duration)
 	TaskStarted(ctx context.Context)
 	TaskFinished(ctx context.Context, dur time.Duration, err error, panicked bool)
@@ -64,7 +119,8 @@ type Observer interface {
 // Scope owns a set of tasks and provides an explicit join point via Wait.
 type Scope struct {
 	ctx      context.Context
-	cancel   context.CancelFunc
+	parent   context.Context
+	cancel   context.CancelCauseFunc
 	policy   Policy
 	wg       sync.WaitGroup
 	mu       sync.Mutex
@@ -72,16 +128,59 @@ type Scope struct {
 	canceled bool
 	waiting  bool
 	done     bool
+	// active counts running tasks and child joins. It is updated under mu
+	// together with wg so that tasks may still be added while Wait is in
+	// progress, as long as an owned task is running.
+	active int
+	doneCh chan struct{}
+	// idle is set, and idleCh closed if created, once no task is running
+	// and none can be admitted any more. See Done.
+	idle   bool
+	idleCh chan struct{}
+	// stopIdle unregisters the hook that closes Done when the context is
+	// canceled from outside the scope.
+	stopIdle func() bool
+	result   error
+
+	// reason is classified once, on the first cancellation of any kind;
+	// notified records that Observer.ScopeCancelled has been delivered.
+	reason   CancelReason
+	notified bool
+	// stopNotify unregisters the observer's context.AfterFunc hook.
+	stopNotify func() bool
 
 	// cancelDone is set atomically after Cancel() has recorded the error
 	// and called s.cancel(). Used as a lock-free fast path in fail() to
 	// avoid mutex contention when many goroutines drain simultaneously.
 	cancelDone atomic.Uint32
 
-	opts Options
-	obs  Observer
-	lim  Limiter
-	errs []error
+	opts     Options
+	obs      Observer
+	lim      Limiter
+	failures []TaskFailure
+	panicErr *PanicError
+	seq      int
+	deferred []func(ctx context.Context) error
+	// cleaned is set once Wait has run the last Defer hook.
+	cleaned bool
+	closers map[*closerReg]struct{}
+	// closersDropped is set once Wait has dropped the closers; later
+	// CloseOnCancel registrations close at once.
+	closersDropped bool
+
+	shutdownStarted bool
+	shutdownReq     chan struct{}
+	shutdownTimer   *time.Timer
+
+	timers     *internal.TimerQueue
+	stopTimers func() bool
+
+	// root is the scope this one descends from through Child, or nil for a
+	// scope created with New. flights is only used on roots.
+	root    *Scope
+	flights *flightGroup
+
+	keyed map[any]*keyQueue
 }
 
 // New creates a Scope with the given parent context, policy, and options.
@@ -90,31 +189,89 @@ func New(parent context.Context, policy Policy, optFns ...Option) *Scope {
 		parent = context.Background()
 	}
 	// collect options first
-	s := &Scope{policy: policy, opts: defaultOptions()}
+	s := &Scope{policy: policy, opts: defaultOptions(), doneCh: make(chan struct{})}
 	for _, fn := range optFns {
 		fn(&s.opts)
 	}
 
-	ctx, cancel := deriveContext(parent, s.opts.Deadline, s.opts.Timeout)
-	s.ctx, s.cancel = ctx, cancel
+	deadline, timeout := resolveDeadline(parent, s.opts)
+	ctx, cancel := deriveContext(parent, deadline, timeout)
+	s.ctx, s.parent, s.cancel = withScope(ctx, s), parent, cancel
 	s.obs = s.opts.Observer
 	if s.opts.MaxConcurrency > 0 {
 		s.lim = newSemaphoreLimiter(s.opts.MaxConcurrency)
 	}
-	if s.obs != nil {
-		s.obs.ScopeCreated(ctx)
-	}
+	s.observeCreated()
 	return s
 }
 
-// Context returns the Scope's context.
+// observeCreated reports scope creation and arranges for cancellations that
+// do not go through Cancel (deadline, parent) to reach the observer.
+func (s *Scope) observeCreated() {
+	if s.obs == nil {
+		return
+	}
+	s.obs.ScopeCreated(s.ctx)
+	s.stopNotify = context.AfterFunc(s.ctx, s.contextDone)
+}
+
+func (s *Scope) contextDone() {
+	s.mu.Lock()
+	if s.reason == ReasonNone {
+		s.reason = s.externalReason()
+	}
+	reason := s.reason
+	notify := !s.notified
+	s.notified = true
+	s.mu.Unlock()
+	if notify {
+		s.obs.ScopeCancelled(s.ctx, reason, context.Cause(s.ctx))
+	}
+}
+
+// externalReason classifies a cancellation the scope did not initiate itself.
+func (s *Scope) externalReason() CancelReason {
+	if s.parent != nil && s.parent.Err() != nil {
+		return ReasonParent
+	}
+	return ReasonDeadline
+}
+
+// CancelReason reports why the scope was canceled, or ReasonNone while its
+// context is still active.
+func (s *Scope) CancelReason() CancelReason {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reason == ReasonNone && s.ctx.Err() != nil {
+		s.reason = s.externalReason()
+	}
+	return s.reason
+}
+
+// Context returns the Scope's context. The context carries the Scope, so
+// FromContext called with it, or with any context derived from it, returns
+// s as a Spawner.
 func (s *Scope) Context() context.Context { return s.ctx }
 
+// Remaining reports how much time is left before the scope's deadline. It
+// returns false when the scope has no deadline and 0 once it has passed.
+func (s *Scope) Remaining() (time.Duration, bool) {
+	deadline, ok := s.ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return max(time.Until(deadline), 0), true
+}
+
 // Go starts a task owned by the Scope.
 //
-// Go is best-effort: if the scope is already canceled, waiting, or done, the
-// task is not started and the call is a no-op. Use TryGo when the caller needs
-// to know whether spawning succeeded.
+// Go is best-effort: if the scope is already canceled or closed, the task is
+// not started and the call is a no-op. Use TryGo when the caller needs to know
+// whether spawning succeeded.
+//
+// Once Wait has started, new tasks are accepted only while other tasks of the
+// scope are still running, so running tasks can fan out further (for example
+// through FromContext). The scope is closed once every task has been joined.
 func (s *Scope) Go(fn func(ctx context.Context) error) {
 	_ = s.TryGo(fn)
 }
@@ -123,105 +280,414 @@ func (s *Scope) Go(fn func(ctx context.Context) error) {
 // succeeded.
 //
 // TryGo returns false when fn is nil or when the scope is no longer accepting
-// new tasks (already canceled, waiting, or done).
+// new tasks (already canceled or closed). Use TryGoErr to learn why.
 func (s *Scope) TryGo(fn func(ctx context.Context) error) bool {
+	return s.TryGoErr(fn) == nil
+}
+
+// TryGoErr starts a task owned by the Scope and returns nil on success.
+//
+// When the task is refused, TryGoErr returns ErrNotAdmitted if fn is nil,
+// ErrScopeCanceled if the scope has been canceled, or ErrScopeClosed if Wait
+// has started and no task is left running, or has completed.
+func (s *Scope) TryGoErr(fn func(ctx context.Context) error) error {
+	return s.spawn("", fn)
+}
+
+// GoNamed is like Go but attaches name to the task. The name is reported in
+// errors produced by the task, such as PanicError.
+func (s *Scope) GoNamed(name string, fn func(ctx context.Context) error) {
+	_ = s.spawn(name, fn)
+}
+
+// Report records err as the failure of a task named name that never ran,
+// such as work skipped because something it needed failed. The failure is
+// handed to the Policy like a task error, but no task is started, so the
+// Limiter and the Observer are not involved. Report does nothing when err is
+// nil or once the scope has been joined.
+func (s *Scope) Report(name string, err error) {
+	if err == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.done {
+		s.mu.Unlock()
+		return
+	}
+	idx := s.seq
+	s.seq++
+	s.mu.Unlock()
+	s.fail(TaskFailure{Name: name, Index: idx, Err: err, Attempt: 1})
+}
+
+func (s *Scope) spawn(name string, fn func(ctx context.Context) error) error {
+	return s.spawnTask(taskSpec{name: name, fn: fn})
+}
+
+// taskSpec describes a task started by spawnTask.
+type taskSpec struct {
+	name string
+	fn   func(ctx context.Context) error
+	// report, when set, maps the error returned by fn to the error handed to
+	// the Policy and its attempt number. A nil error is not reported.
+	// Observers still see the error returned by fn.
+	report func(err error) (error, int)
+	// after, when set, runs once the task's outcome has been recorded.
+	after func()
+}
+
+func (s *Scope) spawnTask(t taskSpec) error {
+	name, fn := t.name, t.fn
 	if fn == nil {
-		return false
+		return ErrNotAdmitted
 	}
 	s.mu.Lock()
-	if s.waiting || s.done || s.canceled {
+	if err := s.reserveLocked(); err != nil {
 		s.mu.Unlock()
-		return false
+		return err
 	}
-	s.wg.Add(1)
+	idx := s.seq
+	s.seq++
 	s.mu.Unlock()
-	go func() {
-		defer s.wg.Done()
+	s.execute(func() {
+		defer s.taskDone()
+		if t.after != nil {
+			defer t.after()
+		}
 		if s.lim != nil {
 			if err := s.lim.Acquire(s.ctx); err != nil {
-				s.fail(err)
+				s.fail(TaskFailure{Name: name, Index: idx, Err: fmt.Errorf("%w: %w", ErrNotAdmitted, err), Attempt: 1})
 				return
 			}
 			defer s.lim.Release()
 		}
+
+		// Durations are only needed for observers and Supervisor failure reports.
+		var start time.Time
+		if s.obs != nil || s.policy == Supervisor {
+			start = time.Now()
+		}
 		defer func() {
 			if r := recover(); r != nil {
-				if s.opts.PanicAsError {
-					err := panicToError(r)
-					s.fail(err)
+				dur := since(start)
+				if s.opts.Repanic {
+					pe := panicToError(r, name)
+					s.recordPanic(pe)
+					if s.obs != nil {
+						s.obs.TaskFinished(s.ctx, dur, pe, true)
+					}
+				} else if s.opts.PanicAsError {
+					err := panicToError(r, name)
+					var reported error = err
+					attempt := 1
+					if t.report != nil {
+						reported, attempt = t.report(err)
+					}
+					if reported != nil {
+						s.fail(TaskFailure{Name: name, Index: idx, Err: reported, Duration: dur, Panicked: true, Attempt: attempt})
+					}
 					if s.obs != nil {
-						s.obs.TaskFinished(s.ctx, 0, err, true)
+						s.obs.TaskFinished(s.ctx, dur, err, true)
 					}
 				} else {
 					if s.obs != nil {
-						s.obs.TaskFinished(s.ctx, 0, nil, true)
+						s.obs.TaskFinished(s.ctx, dur, nil, true)
 					}
 					panic(r)
 				}
 			}
 		}()
 
-		var start time.Time
 		if s.obs != nil {
-			start = time.Now()
 			s.obs.TaskStarted(s.ctx)
 		}
 
 		err := fn(s.ctx)
-		if err != nil {
-			s.fail(err)
+		reported, attempt := err, 1
+		if t.report != nil {
+			reported, attempt = t.report(err)
+		}
+		if reported != nil {
+			s.fail(TaskFailure{Name: name, Index: idx, Err: reported, Duration: since(start), Attempt: attempt})
 		}
 		if s.obs != nil {
 			s.obs.TaskFinished(s.ctx, time.Since(start), err, false)
 		}
-	}()
-	return true
+	})
+	return nil
+}
+
+// execute runs a task body on the configured Executor.
+func (s *Scope) execute(fn func()) {
+	if s.opts.Executor == nil {
+		go fn()
+		return
+	}
+	s.opts.Executor.Execute(fn)
 }
 
 // Cancel cancels the Scope and records the first non-nil error as the cause.
-func (s *Scope) Cancel(err error) {
+// The recorded cause is reported by context.Cause for the scope's context and
+// every context derived from it, including those of child scopes.
+func (s *Scope) Cancel(err error) { s.cancelWith(ReasonManual, err) }
+
+// Shutdown is like Cancel but classifies the cancellation as ReasonShutdown.
+func (s *Scope) Shutdown(err error) { s.cancelWith(ReasonShutdown, err) }
+
+func (s *Scope) cancelWith(reason CancelReason, err error) {
 	s.mu.Lock()
-	wasCanceled := s.canceled
 	s.canceled = true
+	s.markIdleLocked()
 	if s.firstErr == nil && err != nil {
 		s.firstErr = err
 	}
 	cause := s.firstErr
+	if s.reason == ReasonNone {
+		if s.ctx.Err() != nil {
+			s.reason = s.externalReason()
+		} else {
+			s.reason = reason
+		}
+	}
+	reason = s.reason
+	notify := !s.notified && s.obs != nil
+	s.notified = true
 	s.mu.Unlock()
 
-	s.cancel()
+	s.cancel(cause)
 	s.cancelDone.Store(1)
 
-	if !wasCanceled && s.obs != nil {
-		s.obs.ScopeCancelled(s.ctx, cause)
+	if notify {
+		s.obs.ScopeCancelled(s.ctx, reason, cause)
 	}
 }
 
 // Wait blocks until all owned tasks complete and returns the recorded error, if any.
+// Wait may be called concurrently and repeatedly; every call returns the
+// result of the first join.
+//
+// With WithRepanic, Wait re-panics with the *PanicError of the first task that
+// panicked once all tasks have been joined. A panic re-raised by a child scope
+// is re-panicked the same way unless s converts panics to errors.
 func (s *Scope) Wait() error {
-	var start time.Time
-	if s.obs != nil {
-		start = time.Now()
+	err := s.wait()
+	s.mu.Lock()
+	pe := s.panicErr
+	s.mu.Unlock()
+	if pe != nil {
+		panic(pe)
 	}
+	return err
+}
+
+// wait joins the scope once. Concurrent and later callers block until the
+// first join completes and return its cached result.
+func (s *Scope) wait() error {
 	s.mu.Lock()
+	if s.waiting {
+		s.mu.Unlock()
+		<-s.doneCh
+		return s.result
+	}
 	s.waiting = true
+	s.markIdleLocked()
 	s.mu.Unlock()
+	// Other callers block on doneCh, so it is closed even if teardown panics.
+	defer close(s.doneCh)
+
+	var start time.Time
+	if s.obs != nil {
+		start = time.Now()
+	}
 	s.wg.Wait()
+	s.stopShutdownTimer()
+	s.dropTimers()
+	s.dropClosers()
+	cleanupErr := s.runDeferred()
 	s.mu.Lock()
 	s.done = true
+	s.result = s.collectResult()
+	if cleanupErr != nil {
+		s.result = errors.Join(s.result, cleanupErr)
+	}
 	s.mu.Unlock()
+	if s.stopNotify != nil {
+		s.stopNotify()
+	}
 	if s.obs != nil {
 		s.obs.ScopeJoined(s.ctx, time.Since(start))
 	}
+	return s.result
+}
+
+// Defer registers fn to run after every task of the scope has finished,
+// including after a failure or cancellation. Hooks run in LIFO order from
+// Wait, before it returns, with a context that is not canceled with the scope
+// but carries its values and is bounded by WithCleanupTimeout. Their errors
+// are joined into Wait's result; a panicking hook is recovered and its
+// *PanicError joined the same way, and the remaining hooks still run.
+//
+// Defer on a scope that has already been joined runs fn immediately and
+// discards its error.
+func (s *Scope) Defer(fn func(ctx context.Context) error) {
+	if fn == nil {
+		return
+	}
 	s.mu.Lock()
-	defer s.mu.Unlock()
-	if s.policy == Supervisor && len(s.errs) > 0 {
-		return errors.Join(s.errs...)
+	if !s.cleaned {
+		s.deferred = append(s.deferred, fn)
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+	ctx, cancel := s.cleanupContext()
+	defer cancel()
+	_ = fn(ctx)
+}
+
+// runDeferred runs Defer hooks in LIFO order, including hooks registered by
+// other hooks, and joins their errors.
+func (s *Scope) runDeferred() error {
+	var errs []error
+	var ctx context.Context
+	cancel := func() {}
+	defer func() { cancel() }()
+	for {
+		s.mu.Lock()
+		n := len(s.deferred)
+		if n == 0 {
+			s.cleaned = true
+			s.mu.Unlock()
+			break
+		}
+		fn := s.deferred[n-1]
+		s.deferred = s.deferred[:n-1]
+		s.mu.Unlock()
+		if ctx == nil {
+			ctx, cancel = s.cleanupContext()
+		}
+		if err := runHook(ctx, fn); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runHook calls a Defer hook, turning a panic into a *PanicError.
+func runHook(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicToError(r, "")
+		}
+	}()
+	return fn(ctx)
+}
+
+func (s *Scope) cleanupContext() (context.Context, context.CancelFunc) {
+	ctx := context.WithoutCancel(s.ctx)
+	if s.opts.CleanupTimeout > 0 {
+		return context.WithTimeout(ctx, s.opts.CleanupTimeout)
+	}
+	return ctx, func() {}
+}
+
+// collectResult builds the error returned by Wait. It requires s.mu.
+func (s *Scope) collectResult() error {
+	if s.policy == Supervisor && len(s.failures) > 0 {
+		failures := s.failures
+		if s.opts.DropCollateral {
+			failures = selectFailures(failures, false)
+		}
+		if len(failures) > 0 {
+			return &ScopeError{failures: failures}
+		}
 	}
 	return s.firstErr
 }
 
-func (s *Scope) fail(err error) {
+// closedLocked reports whether the scope no longer admits tasks because it has
+// been joined, or Wait has started with nothing left running. It requires s.mu.
+func (s *Scope) closedLocked() bool {
+	return s.done || (s.waiting && s.active == 0)
+}
+
+// reserveLocked admits one more task, returning ErrScopeCanceled or
+// ErrScopeClosed when the scope no longer accepts work. It requires s.mu.
+func (s *Scope) reserveLocked() error {
+	switch {
+	case s.canceledLocked():
+		return ErrScopeCanceled
+	case s.closedLocked():
+		return ErrScopeClosed
+	}
+	s.addLocked()
+	return nil
+}
+
+// addLocked registers a new task or child join. It requires s.mu.
+func (s *Scope) addLocked() {
+	s.active++
+	s.wg.Add(1)
+}
+
+// taskDone unregisters a task or child join. active drops under mu before the
+// WaitGroup is released, so addLocked never races a WaitGroup reaching zero.
+func (s *Scope) taskDone() {
+	s.mu.Lock()
+	s.active--
+	s.markIdleLocked()
+	s.mu.Unlock()
+	s.wg.Done()
+}
+
+// canceledLocked reports whether the scope refuses new tasks because it was
+// canceled, by Cancel, a failing task, or its own context. It requires s.mu.
+func (s *Scope) canceledLocked() bool {
+	return s.canceled || s.ctx.Err() != nil
+}
+
+// markIdleLocked closes Done once the scope has no running task and admits
+// no new ones, which holds for good once it is canceled or waiting. It
+// requires s.mu.
+func (s *Scope) markIdleLocked() {
+	if s.idle || s.active > 0 || !(s.waiting || s.canceledLocked()) {
+		return
+	}
+	s.idle = true
+	if s.idleCh != nil {
+		close(s.idleCh)
+	}
+	if s.stopIdle != nil {
+		s.stopIdle()
+	}
+}
+
+// recordPanic keeps the first panic for Wait to re-panic and cancels siblings.
+func (s *Scope) recordPanic(pe *PanicError) {
+	s.mu.Lock()
+	if s.panicErr == nil {
+		s.panicErr = pe
+	}
+	s.mu.Unlock()
+	s.cancelWith(ReasonSiblingFailure, pe)
+}
+
+// propagatePanic hands a panic re-raised by a child scope to s according to
+// s's own panic handling options. f describes the child as a task of s.
+// It runs on the child's join goroutine, so it never panics itself: unless
+// s turns panics into errors, the panic is recorded and re-raised by s.Wait
+// on the goroutine that owns s.
+func (s *Scope) propagatePanic(pe *PanicError, f TaskFailure) {
+	if s.opts.PanicAsError && !s.opts.Repanic {
+		f.Err, f.Panicked = pe, true
+		s.fail(f)
+		return
+	}
+	s.recordPanic(pe)
+}
+
+func (s *Scope) fail(f TaskFailure) {
+	err := f.Err
 	if err == nil {
 		return
 	}
@@ -233,7 +699,8 @@ func (s *Scope) fail(err error) {
 	}
 	s.mu.Lock()
 	if s.policy == Supervisor {
-		s.errs = append(s.errs, err)
+		f.Collateral = isCollateral(s.ctx, err)
+		s.failures = append(s.failures, f)
 	}
 	if s.firstErr == nil {
 		s.firstErr = err
@@ -242,46 +709,77 @@ func (s *Scope) fail(err error) {
 	cause := s.firstErr
 	s.mu.Unlock()
 	if shouldCancel {
-		s.Cancel(cause)
+		s.cancelWith(ReasonSiblingFailure, cause)
 	}
 }
 
 // Child creates a child Scope inheriting options; parent cancellation cancels the child.
 func (s *Scope) Child(policy Policy, optFns ...Option) *Scope {
 	s.mu.Lock()
-	if s.waiting || s.done {
+	if s.closedLocked() || s.canceledLocked() {
 		s.mu.Unlock()
-		ctx, cancel := context.WithCancel(s.ctx)
-		cancel()
-		return &Scope{
-			ctx:    ctx,
-			cancel: cancel,
-			policy: policy,
-			opts:   defaultOptions(),
+		ctx, cancel := context.WithCancelCause(s.ctx)
+		cancel(ErrScopeClosed)
+		cs := &Scope{
+			parent:   s.ctx,
+			cancel:   cancel,
+			policy:   policy,
+			opts:     defaultOptions(),
+			canceled: true,
+			reason:   ReasonParent,
+			doneCh:   make(chan struct{}),
+			root:     s.treeRoot(),
 		}
+		cs.ctx = withScope(ctx, cs)
+		return cs
 	}
-	s.wg.Add(1)
+	idx := s.seq
+	s.seq++
+	s.addLocked()
 	s.mu.Unlock()
+	created := time.Now()
 
 	childOpts := s.opts
 	for _, fn := range optFns {
 		fn(&childOpts)
 	}
-	ctx, cancel := deriveContext(s.ctx, childOpts.Deadline, childOpts.Timeout)
-	cs := &Scope{ctx: ctx, cancel: cancel, policy: policy, opts: childOpts, obs: childOpts.Observer}
+	deadline, timeout := resolveDeadline(s.ctx, childOpts)
+	ctx, cancel := deriveContext(s.ctx, deadline, timeout)
+	cs := &Scope{
+		parent: s.ctx,
+		cancel: cancel,
+		policy: policy,
+		opts:   childOpts,
+		obs:    childOpts.Observer,
+		doneCh: make(chan struct{}),
+		root:   s.treeRoot(),
+	}
+	cs.ctx = withScope(ctx, cs)
 	if childOpts.MaxConcurrency > 0 {
 		cs.lim = newSemaphoreLimiter(childOpts.MaxConcurrency)
 	}
-	if cs.obs != nil {
-		cs.obs.ScopeCreated(ctx)
-	}
+	cs.observeCreated()
 
 	go func() {
-		defer s.wg.Done()
-		if err := cs.Wait(); err != nil {
-			s.fail(err)
+		defer s.taskDone()
+		err := cs.wait()
+		cs.mu.Lock()
+		pe := cs.panicErr
+		cs.mu.Unlock()
+		f := TaskFailure{Index: idx, Err: err, Duration: time.Since(created), Attempt: 1}
+		if pe != nil {
+			s.propagatePanic(pe, f)
+		} else if err != nil {
+			s.fail(f)
 		}
 	}()
 
 	return cs
 }
+
+func since(t time.Time) time.Duration {
+	if t.IsZero() {
+		return 0
+	}
+	return time.Since(t)
+}
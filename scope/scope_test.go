@@ -315,8 +315,10 @@ type countObserver struct {
 	cancel   atomic.Int64
 }
 
-func (o *countObserver) ScopeCreated(_ context.Context)                 {}
-func (o *countObserver) ScopeCancelled(_ context.Context, _ error)      { o.cancel.Add(1) }
+func (o *countObserver) ScopeCreated(_ context.Context) {}
+func (o *countObserver) ScopeCancelled(_ context.Context, _ CancelReason, _ error) {
+	o.cancel.Add(1)
+}
 func (o *countObserver) ScopeJoined(_ context.Context, _ time.Duration) { o.joined.Add(1) }
 func (o *countObserver) TaskStarted(_ context.Context)                  { o.started.Add(1) }
 func (o *countObserver) TaskFinished(_ context.Context, _ time.Duration, _ error, _ bool) {
@@ -400,3 +402,254 @@ func TestTryGoRejectsAfterCancel(t *testing.T) {
 		t.Fatal("TryGo should reject task after Cancel")
 	}
 }
+
+func TestTryGoErrReportsReason(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast)
+	if err := s.TryGoErr(nil); !errors.Is(err, ErrNotAdmitted) {
+		t.Fatalf("TryGoErr(nil): want ErrNotAdmitted, got %v", err)
+	}
+	if err := s.TryGoErr(func(_ context.Context) error { return nil }); err != nil {
+		t.Fatalf("TryGoErr on active scope: %v", err)
+	}
+	if err := s.Wait(); err != nil {
+		t.Fatalf("unexpected wait error: %v", err)
+	}
+	if err := s.TryGoErr(func(_ context.Context) error { return nil }); !errors.Is(err, ErrScopeClosed) {
+		t.Fatalf("TryGoErr after Wait: want ErrScopeClosed, got %v", err)
+	}
+
+	c := New(context.Background(), FailFast)
+	c.Cancel(errors.New("stop"))
+	if err := c.TryGoErr(func(_ context.Context) error { return nil }); !errors.Is(err, ErrScopeCanceled) {
+		t.Fatalf("TryGoErr after Cancel: want ErrScopeCanceled, got %v", err)
+	}
+	_ = c.Wait()
+}
+
+func TestRepanicInWait(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), Supervisor, WithRepanic(true))
+	siblingCanceled := make(chan struct{})
+	s.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		close(siblingCanceled)
+		return ctx.Err()
+	})
+	s.GoNamed("crasher", func(_ context.Context) error {
+		panic("repanic-value")
+	})
+
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		_ = s.Wait()
+	}()
+
+	pe, ok := recovered.(*PanicError)
+	if !ok {
+		t.Fatalf("expected Wait to re-panic with *PanicError, got %T: %v", recovered, recovered)
+	}
+	if pe.Value() != "repanic-value" || pe.Task() != "crasher" {
+		t.Fatalf("unexpected panic error: value=%v task=%q", pe.Value(), pe.Task())
+	}
+	if len(pe.Stack()) == 0 {
+		t.Fatal("expected task stack attached to re-panic")
+	}
+	select {
+	case <-siblingCanceled:
+	default:
+		t.Fatal("sibling should be canceled when a task panics")
+	}
+}
+
+func TestRepanicPropagatesFromChild(t *testing.T) {
+	t.Parallel()
+	parent := New(context.Background(), FailFast, WithRepanic(true))
+	child := parent.Child(Supervisor)
+	child.Go(func(_ context.Context) error {
+		panic("child-panic")
+	})
+
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		_ = parent.Wait()
+	}()
+	if pe, ok := recovered.(*PanicError); !ok || pe.Value() != "child-panic" {
+		t.Fatalf("expected child panic to re-panic from parent Wait, got %v", recovered)
+	}
+}
+
+func TestChildRepanicRecoveredByParentWait(t *testing.T) {
+	t.Parallel()
+	parent := New(context.Background(), FailFast, WithPanicAsError(false))
+	child := parent.Child(FailFast, WithRepanic(true))
+	child.Go(func(_ context.Context) error {
+		panic("child-panic")
+	})
+
+	recoverWait := func(s *Scope) (recovered any) {
+		defer func() { recovered = recover() }()
+		_ = s.Wait()
+		return nil
+	}
+	if pe, ok := recoverWait(child).(*PanicError); !ok || pe.Value() != "child-panic" {
+		t.Fatal("expected child Wait to re-panic with the task panic")
+	}
+	if pe, ok := recoverWait(parent).(*PanicError); !ok || pe.Value() != "child-panic" {
+		t.Fatal("expected parent Wait to re-panic with the child panic")
+	}
+}
+
+func TestContextCauseReportsTaskError(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	parent := New(context.Background(), FailFast)
+	child := parent.Child(Supervisor)
+	causes := make(chan error, 2)
+	parent.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		causes <- context.Cause(ctx)
+		return ctx.Err()
+	})
+	child.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		causes <- context.Cause(ctx)
+		return ctx.Err()
+	})
+	parent.Go(func(_ context.Context) error { return boom })
+
+	if err := parent.Wait(); !errors.Is(err, boom) {
+		t.Fatalf("unexpected wait error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if got := <-causes; !errors.Is(got, boom) {
+			t.Fatalf("expected context.Cause to be the failing task's error, got %v", got)
+		}
+	}
+}
+
+func TestCancelSetsContextCause(t *testing.T) {
+	t.Parallel()
+	stop := errors.New("stop")
+	s := New(context.Background(), Supervisor)
+	s.Cancel(stop)
+	if got := context.Cause(s.Context()); !errors.Is(got, stop) {
+		t.Fatalf("expected Cancel cause, got %v", got)
+	}
+	_ = s.Wait()
+}
+
+type reasonObserver struct {
+	NopObserver
+	reasons chan CancelReason
+}
+
+func (o *reasonObserver) ScopeCancelled(_ context.Context, reason CancelReason, _ error) {
+	o.reasons <- reason
+}
+
+func TestCancelReason(t *testing.T) {
+	t.Parallel()
+	blockUntilDone := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	manual := New(context.Background(), Supervisor)
+	if got := manual.CancelReason(); got != ReasonNone {
+		t.Fatalf("active scope: want ReasonNone, got %v", got)
+	}
+	manual.Cancel(errors.New("stop"))
+	manual.Shutdown(nil)
+	if got := manual.CancelReason(); got != ReasonManual {
+		t.Fatalf("want ReasonManual, got %v", got)
+	}
+	_ = manual.Wait()
+
+	shutdown := New(context.Background(), Supervisor)
+	shutdown.Shutdown(nil)
+	if got := shutdown.CancelReason(); got != ReasonShutdown {
+		t.Fatalf("want ReasonShutdown, got %v", got)
+	}
+	_ = shutdown.Wait()
+
+	failing := New(context.Background(), FailFast)
+	failing.Go(func(_ context.Context) error { return errors.New("boom") })
+	_ = failing.Wait()
+	if got := failing.CancelReason(); got != ReasonSiblingFailure {
+		t.Fatalf("want ReasonSiblingFailure, got %v", got)
+	}
+
+	obs := &reasonObserver{reasons: make(chan CancelReason, 1)}
+	timed := New(context.Background(), FailFast, WithTimeout(5*time.Millisecond), WithObserver(obs))
+	timed.Go(blockUntilDone)
+	_ = timed.Wait()
+	if got := timed.CancelReason(); got != ReasonDeadline {
+		t.Fatalf("want ReasonDeadline, got %v", got)
+	}
+	if got := <-obs.reasons; got != ReasonDeadline {
+		t.Fatalf("observer: want ReasonDeadline, got %v", got)
+	}
+
+	parent := New(context.Background(), Supervisor)
+	child := parent.Child(Supervisor)
+	child.Go(blockUntilDone)
+	parent.Cancel(nil)
+	_ = parent.Wait()
+	if got := child.CancelReason(); got != ReasonParent {
+		t.Fatalf("want ReasonParent, got %v", got)
+	}
+}
+
+func TestChildBudgetAndRemaining(t *testing.T) {
+	t.Parallel()
+	parent := New(context.Background(), FailFast)
+	if _, ok := parent.Remaining(); ok {
+		t.Fatal("scope without deadline should report no remaining budget")
+	}
+	_ = parent.Wait()
+
+	parent = New(context.Background(), FailFast, WithTimeout(400*time.Millisecond))
+	child := parent.Child(FailFast, WithBudgetFraction(0.5), WithBudgetReserve(300*time.Millisecond))
+	rem, ok := child.Remaining()
+	if !ok {
+		t.Fatal("child should have a deadline")
+	}
+	if rem > 110*time.Millisecond {
+		t.Fatalf("reserve should leave the child at most 100ms, got %v", rem)
+	}
+	child.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err := child.Wait(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected child deadline, got %v", err)
+	}
+	if parentRem, _ := parent.Remaining(); parentRem <= 0 {
+		t.Fatal("parent should still have time left after the child's budget expired")
+	}
+	_ = parent.Wait()
+}
+
+func TestReportRecordsFailureWithoutTask(t *testing.T) {
+	t.Parallel()
+	skipped := errors.New("skipped")
+	obs := &countObserver{}
+	s := New(context.Background(), Supervisor, WithObserver(obs))
+	s.Report("never-ran", skipped)
+	s.Report("ignored", nil)
+	err := s.Wait()
+	var se *ScopeError
+	if !errors.As(err, &se) || se.Count() != 1 || se.Failures()[0].Name != "never-ran" {
+		t.Fatalf("Wait() = %v, want one failure named never-ran", err)
+	}
+	if n := obs.started.Load(); n != 0 {
+		t.Fatalf("observer saw %d task starts, want none", n)
+	}
+	s.Report("late", skipped) // after the join: ignored
+	if !errors.As(s.Err(), &se) || se.Count() != 1 {
+		t.Fatalf("Err() = %v after a late Report, want the joined result", s.Err())
+	}
+}
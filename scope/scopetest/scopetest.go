@@ -0,0 +1,289 @@
+// Package scopetest provides fake [scope.Spawner] implementations for
+// deterministic unit tests of code that spawns scoped tasks.
+//
+//   - [Inline] runs each task synchronously inside Go/TryGo.
+//   - [Recorder] records tasks without running them.
+//   - [Manual] queues tasks and runs them one at a time on demand.
+//
+// SpawnChild returns a fake of the same kind, so code under test keeps the
+// parent's discipline in its child scopes too. Every fake also implements
+// [scope.ChildSpawner]; see each type's Wait.
+//
+// Each fake attaches itself to its context with [scope.ContextWithSpawner],
+// so [scope.FromContext] called with a task's context returns the fake that
+// runs the task, as it returns the enclosing scope for a real one.
+package scopetest
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/NetPo4ki/go-scope/scope"
+)
+
+// Task is a task function as passed to [scope.Spawner.Go].
+type Task = func(ctx context.Context) error
+
+// Inline is a [scope.Spawner] that runs every task synchronously in the
+// caller's goroutine and records its error.
+type Inline struct {
+	ctx    context.Context
+	parent *Inline
+	mu     sync.Mutex
+	errs   []error
+	runs   int
+}
+
+// NewInline returns an Inline spawner whose tasks receive ctx.
+// A nil ctx is treated as context.Background().
+func NewInline(ctx context.Context) *Inline {
+	i := &Inline{}
+	i.ctx = scope.ContextWithSpawner(orBackground(ctx), i)
+	return i
+}
+
+// Go runs fn immediately.
+func (i *Inline) Go(fn Task) { _ = i.TryGo(fn) }
+
+// TryGo runs fn immediately and reports false only when fn is nil.
+func (i *Inline) TryGo(fn Task) bool {
+	if fn == nil {
+		return false
+	}
+	err := fn(i.ctx)
+	i.mu.Lock()
+	i.runs++
+	i.mu.Unlock()
+	if err != nil {
+		i.fail(err)
+	}
+	return true
+}
+
+// fail records err on i and, like a failing child scope, on its ancestors.
+func (i *Inline) fail(err error) {
+	for ; i != nil; i = i.parent {
+		i.mu.Lock()
+		i.errs = append(i.errs, err)
+		i.mu.Unlock()
+	}
+}
+
+// SpawnChild returns an Inline deriving from i's context whose task errors
+// are also recorded on i. The policy and options are ignored.
+func (i *Inline) SpawnChild(scope.Policy, ...scope.Option) scope.ChildSpawner {
+	c := &Inline{parent: i}
+	c.ctx = scope.ContextWithSpawner(i.ctx, c)
+	return c
+}
+
+// Wait returns Err; every task has already run by the time Go returns.
+func (i *Inline) Wait() error { return i.Err() }
+
+// Context returns the context passed to tasks.
+func (i *Inline) Context() context.Context { return i.ctx }
+
+// Runs returns how many tasks have run.
+func (i *Inline) Runs() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.runs
+}
+
+// Err returns the errors of all tasks run so far, joined with errors.Join.
+func (i *Inline) Err() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return errors.Join(i.errs...)
+}
+
+// Recorder is a [scope.Spawner] that records tasks without running them.
+type Recorder struct {
+	ctx      context.Context
+	mu       sync.Mutex
+	tasks    []Task
+	children []*Recorder
+}
+
+// NewRecorder returns a Recorder whose Context returns ctx.
+// A nil ctx is treated as context.Background().
+func NewRecorder(ctx context.Context) *Recorder {
+	r := &Recorder{}
+	r.ctx = scope.ContextWithSpawner(orBackground(ctx), r)
+	return r
+}
+
+// Go records fn.
+func (r *Recorder) Go(fn Task) { _ = r.TryGo(fn) }
+
+// TryGo records fn and reports false only when fn is nil.
+func (r *Recorder) TryGo(fn Task) bool {
+	if fn == nil {
+		return false
+	}
+	r.mu.Lock()
+	r.tasks = append(r.tasks, fn)
+	r.mu.Unlock()
+	return true
+}
+
+// SpawnChild returns a new Recorder deriving from r's context and lists it
+// in r.Children. The policy and options are ignored.
+func (r *Recorder) SpawnChild(scope.Policy, ...scope.Option) scope.ChildSpawner {
+	c := &Recorder{}
+	c.ctx = scope.ContextWithSpawner(r.ctx, c)
+	r.mu.Lock()
+	r.children = append(r.children, c)
+	r.mu.Unlock()
+	return c
+}
+
+// Wait returns nil at once; recorded tasks never run by themselves.
+func (r *Recorder) Wait() error { return nil }
+
+// Children returns the Recorders created by SpawnChild in creation order.
+func (r *Recorder) Children() []*Recorder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]*Recorder, len(r.children))
+	copy(out, r.children)
+	return out
+}
+
+// Context returns the Recorder's context.
+func (r *Recorder) Context() context.Context { return r.ctx }
+
+// Tasks returns the recorded tasks in spawn order.
+func (r *Recorder) Tasks() []Task {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]Task, len(r.tasks))
+	copy(out, r.tasks)
+	return out
+}
+
+// Len returns the number of recorded tasks.
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.tasks)
+}
+
+// Manual is a [scope.Spawner] that queues tasks until the test runs them with
+// Step or RunAll. Tasks spawned by a running task are appended to the queue.
+type Manual struct {
+	ctx  context.Context
+	root *Manual // owns the queue shared with children
+	mu   sync.Mutex
+	errs []error
+
+	queue []manualTask // only used on root
+}
+
+type manualTask struct {
+	fn    Task
+	owner *Manual
+}
+
+// NewManual returns a Manual spawner whose tasks receive ctx.
+// A nil ctx is treated as context.Background().
+func NewManual(ctx context.Context) *Manual {
+	m := &Manual{}
+	m.ctx = scope.ContextWithSpawner(orBackground(ctx), m)
+	m.root = m
+	return m
+}
+
+// Go queues fn.
+func (m *Manual) Go(fn Task) { _ = m.TryGo(fn) }
+
+// TryGo queues fn and reports false only when fn is nil.
+func (m *Manual) TryGo(fn Task) bool {
+	if fn == nil {
+		return false
+	}
+	r := m.root
+	r.mu.Lock()
+	r.queue = append(r.queue, manualTask{fn: fn, owner: m})
+	r.mu.Unlock()
+	return true
+}
+
+// SpawnChild returns a Manual deriving from m's context and sharing its
+// queue: its tasks are run by Step and RunAll on any Manual of the tree, in
+// spawn order. The policy and options are ignored.
+func (m *Manual) SpawnChild(scope.Policy, ...scope.Option) scope.ChildSpawner {
+	c := &Manual{root: m.root}
+	c.ctx = scope.ContextWithSpawner(m.ctx, c)
+	return c
+}
+
+// Wait does not run anything; it returns the joined errors of m's own tasks
+// that have run so far.
+func (m *Manual) Wait() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return errors.Join(m.errs...)
+}
+
+// Context returns the context passed to tasks.
+func (m *Manual) Context() context.Context { return m.ctx }
+
+// Pending returns the number of queued tasks.
+func (m *Manual) Pending() int {
+	r := m.root
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.queue)
+}
+
+// Step runs the oldest queued task in the caller's goroutine. It reports
+// whether a task ran, along with that task's error.
+func (m *Manual) Step() (bool, error) {
+	r := m.root
+	r.mu.Lock()
+	if len(r.queue) == 0 {
+		r.mu.Unlock()
+		return false, nil
+	}
+	t := r.queue[0]
+	r.queue[0] = manualTask{}
+	r.queue = r.queue[1:]
+	r.mu.Unlock()
+	err := t.fn(t.owner.ctx)
+	if err != nil {
+		t.owner.mu.Lock()
+		t.owner.errs = append(t.owner.errs, err)
+		t.owner.mu.Unlock()
+	}
+	return true, err
+}
+
+// RunAll steps until the queue is empty, including tasks queued while running,
+// and returns the joined errors of every task it ran.
+func (m *Manual) RunAll() error {
+	var errs []error
+	for {
+		ran, err := m.Step()
+		if !ran {
+			return errors.Join(errs...)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+}
+
+func orBackground(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
+}
+
+var (
+	_ scope.ChildSpawner = (*Inline)(nil)
+	_ scope.ChildSpawner = (*Recorder)(nil)
+	_ scope.ChildSpawner = (*Manual)(nil)
+)
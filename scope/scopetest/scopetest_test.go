@@ -0,0 +1,170 @@
+package scopetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NetPo4ki/go-scope/scope"
+)
+
+// fanOut is a stand-in for library code that accepts a scope.Spawner.
+func fanOut(sp scope.Spawner, n int, out []int) {
+	for i := 0; i < n; i++ {
+		sp.Go(func(_ context.Context) error {
+			out[i] = i * i
+			return nil
+		})
+	}
+}
+
+func TestInlineRunsSynchronously(t *testing.T) {
+	t.Parallel()
+	in := NewInline(context.Background())
+	out := make([]int, 3)
+	fanOut(in, 3, out)
+	if out[2] != 4 || in.Runs() != 3 {
+		t.Fatalf("tasks should have run inline, got out=%v runs=%d", out, in.Runs())
+	}
+	boom := errors.New("boom")
+	in.Go(func(_ context.Context) error { return boom })
+	if !errors.Is(in.Err(), boom) {
+		t.Fatalf("expected recorded error, got %v", in.Err())
+	}
+	if in.TryGo(nil) {
+		t.Fatal("TryGo(nil) should return false")
+	}
+}
+
+func TestRecorderDoesNotRun(t *testing.T) {
+	t.Parallel()
+	rec := NewRecorder(nil)
+	out := make([]int, 2)
+	fanOut(rec, 2, out)
+	if rec.Len() != 2 || out[1] != 0 {
+		t.Fatalf("tasks should be recorded only, got len=%d out=%v", rec.Len(), out)
+	}
+	if err := rec.Tasks()[1](rec.Context()); err != nil || out[1] != 1 {
+		t.Fatalf("recorded task should be runnable on demand, err=%v out=%v", err, out)
+	}
+}
+
+func TestManualSteps(t *testing.T) {
+	t.Parallel()
+	m := NewManual(context.Background())
+	var order []string
+	m.Go(func(_ context.Context) error {
+		order = append(order, "a")
+		m.Go(func(_ context.Context) error {
+			order = append(order, "c")
+			return errors.New("c failed")
+		})
+		return nil
+	})
+	m.Go(func(_ context.Context) error {
+		order = append(order, "b")
+		return nil
+	})
+
+	if ran, err := m.Step(); !ran || err != nil || len(order) != 1 {
+		t.Fatalf("first step: ran=%v err=%v order=%v", ran, err, order)
+	}
+	if m.Pending() != 2 {
+		t.Fatalf("expected 2 pending tasks, got %d", m.Pending())
+	}
+	if err := m.RunAll(); err == nil || err.Error() != "c failed" {
+		t.Fatalf("RunAll should return task errors, got %v", err)
+	}
+	if got := len(order); got != 3 || order[1] != "b" || order[2] != "c" {
+		t.Fatalf("unexpected order: %v", order)
+	}
+	if ran, _ := m.Step(); ran {
+		t.Fatal("Step on empty queue should report false")
+	}
+}
+
+// nested is a stand-in for library code that groups work in a child scope.
+func nested(sp scope.Spawner, out []int) error {
+	c := sp.SpawnChild(scope.FailFast)
+	fanOut(c, len(out), out)
+	return c.Wait()
+}
+
+func TestChildrenKeepTheFakesDiscipline(t *testing.T) {
+	t.Parallel()
+	in := NewInline(context.Background())
+	out := make([]int, 3)
+	if err := nested(in, out); err != nil || out[2] != 4 {
+		t.Fatalf("inline child should run at once, err=%v out=%v", err, out)
+	}
+	boom := errors.New("boom")
+	in.SpawnChild(scope.FailFast).Go(func(_ context.Context) error { return boom })
+	if !errors.Is(in.Err(), boom) {
+		t.Fatalf("inline child error should reach the parent, got %v", in.Err())
+	}
+
+	rec := NewRecorder(nil)
+	out = make([]int, 2)
+	if err := nested(rec, out); err != nil || out[1] != 0 {
+		t.Fatalf("recorder child should not run tasks, err=%v out=%v", err, out)
+	}
+	if cs := rec.Children(); len(cs) != 1 || cs[0].Len() != 2 || rec.Len() != 0 {
+		t.Fatalf("child tasks should be recorded on the child, got %d children", len(cs))
+	}
+
+	m := NewManual(context.Background())
+	c := m.SpawnChild(scope.FailFast)
+	c.Go(func(_ context.Context) error { return boom })
+	if m.Pending() != 1 {
+		t.Fatalf("child task should be queued on the shared queue, pending=%d", m.Pending())
+	}
+	if err := c.Wait(); err != nil {
+		t.Fatalf("Wait should not run queued tasks, got %v", err)
+	}
+	if err := m.RunAll(); !errors.Is(err, boom) {
+		t.Fatalf("RunAll should run the child's task, got %v", err)
+	}
+	if err := c.Wait(); !errors.Is(err, boom) {
+		t.Fatalf("child Wait should report its task error, got %v", err)
+	}
+}
+
+func TestTaskContextsCarryTheFake(t *testing.T) {
+	t.Parallel()
+	found := func(ctx context.Context) scope.Spawner {
+		sp, _ := scope.FromContext(ctx)
+		return sp
+	}
+
+	in := NewInline(context.Background())
+	var got scope.Spawner
+	in.Go(func(ctx context.Context) error { got = found(ctx); return nil })
+	if got != in {
+		t.Fatalf("FromContext in an inline task = %v, want the Inline", got)
+	}
+	child := in.SpawnChild(scope.FailFast)
+	child.Go(func(ctx context.Context) error { got = found(ctx); return nil })
+	if got != child {
+		t.Fatalf("FromContext in an inline child task = %v, want the child", got)
+	}
+
+	rec := NewRecorder(nil)
+	if found(rec.Context()) != rec {
+		t.Fatal("FromContext(Recorder.Context()) should return the Recorder")
+	}
+
+	m := NewManual(context.Background())
+	mc := m.SpawnChild(scope.FailFast)
+	m.Go(func(ctx context.Context) error { got = found(ctx); return nil })
+	if _, err := m.Step(); err != nil || got != m {
+		t.Fatalf("FromContext in a manual task = %v, want the Manual", got)
+	}
+	mc.Go(func(ctx context.Context) error {
+		// Spawning through the context queues on the shared queue.
+		found(ctx).Go(func(context.Context) error { return nil })
+		return nil
+	})
+	if _, err := m.Step(); err != nil || m.Pending() != 1 {
+		t.Fatalf("task spawned via FromContext should be queued, pending=%d", m.Pending())
+	}
+}
@@ -0,0 +1,212 @@
+// Package service manages named long-running services on top of a
+// [scope.Scope].
+//
+// Services declare their dependencies and signal readiness. Run starts them
+// in dependency order, waiting for each to become ready before starting the
+// next, and stops them in reverse order when the context is canceled or a
+// service fails. A crashing service follows the scope Policy: with
+// [scope.FailFast] the first crash stops everything, with [scope.Supervisor]
+// the remaining services keep running and the failure is reported by Run.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/NetPo4ki/go-scope/scope"
+)
+
+// Sentinel errors returned by Add and Run.
+var (
+	ErrDuplicate  = errors.New("service: duplicate name")
+	ErrUnknownDep = errors.New("service: unknown dependency")
+	ErrCycle      = errors.New("service: dependency cycle")
+	ErrNotReady   = errors.New("service: exited before ready")
+	ErrStarted    = errors.New("service: manager already started")
+)
+
+// RunFunc runs a service until ctx is canceled. It must call ready once the
+// service can be used by its dependents; calling ready more than once is
+// harmless. Returning after ctx is canceled is a clean stop.
+type RunFunc func(ctx context.Context, ready func()) error
+
+// Manager starts and stops a set of services.
+type Manager struct {
+	policy scope.Policy
+	opts   []scope.Option
+
+	mu      sync.Mutex
+	started bool
+	byName  map[string]*service
+	order   []*service // registration order
+}
+
+type service struct {
+	name  string
+	deps  []string
+	run   RunFunc
+	stop  context.CancelCauseFunc
+	ready chan struct{}
+	done  chan struct{}
+	err   error // set before done is closed
+}
+
+// New creates a Manager whose services run in a Scope with the given policy
+// and options.
+func New(policy scope.Policy, optFns ...scope.Option) *Manager {
+	return &Manager{policy: policy, opts: optFns, byName: make(map[string]*service)}
+}
+
+// Add registers a service that depends on the services named in deps.
+// Dependencies may be registered later; they are resolved by Run.
+func (m *Manager) Add(name string, run RunFunc, deps ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.started {
+		return ErrStarted
+	}
+	if _, ok := m.byName[name]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicate, name)
+	}
+	svc := &service{name: name, deps: deps, run: run}
+	m.byName[name] = svc
+	m.order = append(m.order, svc)
+	return nil
+}
+
+// Run starts all services in dependency order and blocks until they have
+// stopped. Services are stopped in reverse start order when ctx is canceled,
+// when a service fails under FailFast, or when a service exits before it
+// became ready. Run returns the scope's Wait error; services that return
+// after being stopped do not contribute to it. Run may be called once.
+func (m *Manager) Run(ctx context.Context) error {
+	m.mu.Lock()
+	if m.started {
+		m.mu.Unlock()
+		return ErrStarted
+	}
+	m.started = true
+	m.mu.Unlock()
+
+	order, err := m.sort()
+	if err != nil {
+		return err
+	}
+
+	s := scope.New(ctx, m.policy, m.opts...)
+	// base keeps the scope's values but not its cancellation, so each
+	// service can be stopped individually in reverse order.
+	base := context.WithoutCancel(s.Context())
+	allDone := make(chan struct{})
+	running := atomic.Int32{}
+	running.Store(int32(len(order)))
+
+	var started []*service
+	for _, svc := range order {
+		if s.Context().Err() != nil {
+			running.Add(-1)
+			continue
+		}
+		svcCtx, stop := context.WithCancelCause(base)
+		svc.stop, svc.ready, svc.done = stop, make(chan struct{}), make(chan struct{})
+		started = append(started, svc)
+		s.GoNamed(svc.name, func(context.Context) error {
+			defer func() {
+				close(svc.done)
+				if running.Add(-1) == 0 {
+					close(allDone)
+				}
+			}()
+			var once sync.Once
+			err := svc.run(svcCtx, func() { once.Do(func() { close(svc.ready) }) })
+			switch {
+			case err == nil, svcCtx.Err() != nil &&
+				(errors.Is(err, context.Canceled) || errors.Is(err, context.Cause(svcCtx))):
+			default:
+				svc.err = fmt.Errorf("service %s: %w", svc.name, err)
+			}
+			return svc.err
+		})
+		select {
+		case <-svc.ready:
+		case <-svc.done:
+			select {
+			case <-svc.ready:
+			default:
+				// Dependents cannot start, so a failed startup stops the
+				// whole manager under either policy.
+				if svc.err != nil {
+					s.Cancel(svc.err)
+				} else {
+					s.Cancel(fmt.Errorf("%w: %s", ErrNotReady, svc.name))
+				}
+			}
+		case <-s.Context().Done():
+		}
+	}
+	if len(started) == 0 {
+		close(allDone)
+	}
+
+	select {
+	case <-s.Context().Done():
+	case <-allDone:
+	}
+	cause := context.Cause(s.Context())
+	if cause == nil {
+		cause = context.Canceled
+	}
+	for i := len(started) - 1; i >= 0; i-- {
+		started[i].stop(cause)
+		<-started[i].done
+	}
+	return s.Wait()
+}
+
+// sort returns the services in dependency order, keeping registration order
+// among services whose dependencies are satisfied at the same time.
+func (m *Manager) sort() ([]*service, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	indeg := make(map[*service]int, len(m.order))
+	dependents := make(map[*service][]*service, len(m.order))
+	for _, svc := range m.order {
+		for _, d := range svc.deps {
+			dep, ok := m.byName[d]
+			if !ok {
+				return nil, fmt.Errorf("%w: %q needs %q", ErrUnknownDep, svc.name, d)
+			}
+			indeg[svc]++
+			dependents[dep] = append(dependents[dep], svc)
+		}
+	}
+	out := make([]*service, 0, len(m.order))
+	placed := make(map[*service]bool, len(m.order))
+	for len(out) < len(m.order) {
+		progress := false
+		for _, svc := range m.order {
+			if placed[svc] || indeg[svc] > 0 {
+				continue
+			}
+			placed[svc] = true
+			out = append(out, svc)
+			for _, d := range dependents[svc] {
+				indeg[d]--
+			}
+			progress = true
+		}
+		if !progress {
+			var names []string
+			for _, svc := range m.order {
+				if !placed[svc] {
+					names = append(names, svc.name)
+				}
+			}
+			return nil, fmt.Errorf("%w among %q", ErrCycle, names)
+		}
+	}
+	return out, nil
+}
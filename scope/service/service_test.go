@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NetPo4ki/go-scope/scope"
+)
+
+type journal struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (j *journal) add(e string) {
+	j.mu.Lock()
+	j.events = append(j.events, e)
+	j.mu.Unlock()
+}
+
+func (j *journal) get() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]string(nil), j.events...)
+}
+
+func recorded(j *journal, name string) RunFunc {
+	return func(ctx context.Context, ready func()) error {
+		j.add("start " + name)
+		ready()
+		<-ctx.Done()
+		j.add("stop " + name)
+		return ctx.Err()
+	}
+}
+
+func TestRunStartsInOrderStopsInReverse(t *testing.T) {
+	t.Parallel()
+	var j journal
+	m := New(scope.FailFast)
+	_ = m.Add("api", recorded(&j, "api"), "db", "cache")
+	_ = m.Add("cache", recorded(&j, "cache"), "db")
+	_ = m.Add("db", recorded(&j, "db"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- m.Run(ctx) }()
+	for len(j.get()) < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil && !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() = %v", err)
+	}
+	want := []string{"start db", "start cache", "start api", "stop api", "stop cache", "stop db"}
+	if got := j.get(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+}
+
+func TestRunFailFastStopsAll(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	var j journal
+	m := New(scope.FailFast)
+	_ = m.Add("db", recorded(&j, "db"))
+	_ = m.Add("worker", func(ctx context.Context, ready func()) error {
+		ready()
+		time.Sleep(10 * time.Millisecond)
+		return boom
+	}, "db")
+	err := m.Run(context.Background())
+	if !errors.Is(err, boom) {
+		t.Fatalf("Run() = %v, want boom", err)
+	}
+	if got := j.get(); len(got) != 2 || got[1] != "stop db" {
+		t.Fatalf("events = %v", got)
+	}
+}
+
+func TestRunSupervisorKeepsOthers(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	var j journal
+	m := New(scope.Supervisor)
+	_ = m.Add("db", recorded(&j, "db"))
+	crashed := make(chan struct{})
+	_ = m.Add("worker", func(ctx context.Context, ready func()) error {
+		ready()
+		defer close(crashed)
+		return boom
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- m.Run(ctx) }()
+	<-crashed
+	time.Sleep(10 * time.Millisecond)
+	if got := j.get(); len(got) != 1 {
+		t.Fatalf("db stopped after worker crash: %v", got)
+	}
+	cancel()
+	err := <-done
+	var se *scope.ScopeError
+	if !errors.As(err, &se) || !errors.Is(err, boom) || se.Failures()[0].Name != "worker" {
+		t.Fatalf("Run() = %v, want ScopeError with worker failure", err)
+	}
+}
+
+func TestRunStartupFailureSkipsDependents(t *testing.T) {
+	t.Parallel()
+	var j journal
+	m := New(scope.Supervisor)
+	_ = m.Add("db", func(ctx context.Context, ready func()) error { return nil })
+	_ = m.Add("api", recorded(&j, "api"), "db")
+	if err := m.Run(context.Background()); !errors.Is(err, ErrNotReady) {
+		t.Fatalf("Run() = %v, want ErrNotReady", err)
+	}
+	if got := j.get(); len(got) != 0 {
+		t.Fatalf("dependent started: %v", got)
+	}
+}
+
+func TestRunValidatesGraph(t *testing.T) {
+	t.Parallel()
+	noop := func(ctx context.Context, ready func()) error { return nil }
+
+	m := New(scope.FailFast)
+	_ = m.Add("a", noop, "b")
+	_ = m.Add("b", noop, "a")
+	if err := m.Run(context.Background()); !errors.Is(err, ErrCycle) {
+		t.Fatalf("Run() = %v, want ErrCycle", err)
+	}
+
+	m = New(scope.FailFast)
+	_ = m.Add("a", noop, "missing")
+	if err := m.Run(context.Background()); !errors.Is(err, ErrUnknownDep) {
+		t.Fatalf("Run() = %v, want ErrUnknownDep", err)
+	}
+	if err := m.Add("a", noop); !errors.Is(err, ErrStarted) {
+		t.Fatalf("Add() after Run = %v, want ErrStarted", err)
+	}
+
+	m = New(scope.FailFast)
+	_ = m.Add("a", noop)
+	if err := m.Add("a", noop); !errors.Is(err, ErrDuplicate) {
+		t.Fatalf("Add() = %v, want ErrDuplicate", err)
+	}
+}
@@ -0,0 +1,57 @@
+package scope
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrShutdownTimeout is wrapped by the cancellation cause when a graceful
+// shutdown started with RequestShutdown exceeds WithShutdownTimeout.
+var ErrShutdownTimeout = errors.New("scope: graceful shutdown timed out")
+
+// RequestShutdown starts a graceful shutdown: ShutdownRequested is closed so
+// that long-running tasks can finish their current work and return, while the
+// scope's context stays active. When WithShutdownTimeout is set and the scope
+// has not been joined in time, the scope is then canceled with Shutdown and a
+// cause wrapping ErrShutdownTimeout and cause. Only the first call has an effect.
+func (s *Scope) RequestShutdown(cause error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.shutdownStarted {
+		return
+	}
+	s.shutdownStarted = true
+	if s.shutdownReq == nil {
+		s.shutdownReq = make(chan struct{})
+	}
+	close(s.shutdownReq)
+	if d := s.opts.ShutdownTimeout; d > 0 && !s.done {
+		s.shutdownTimer = time.AfterFunc(d, func() {
+			s.Shutdown(fmt.Errorf("%w after %v: %w", ErrShutdownTimeout, d, cause))
+		})
+	}
+}
+
+// ShutdownRequested returns a channel that is closed once RequestShutdown has
+// been called, for example by the first signal received by a scope created
+// with NewFromSignals.
+func (s *Scope) ShutdownRequested() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.shutdownReq == nil {
+		s.shutdownReq = make(chan struct{})
+	}
+	return s.shutdownReq
+}
+
+// stopShutdownTimer releases the RequestShutdown escalation timer once the
+// scope has been joined.
+func (s *Scope) stopShutdownTimer() {
+	s.mu.Lock()
+	t := s.shutdownTimer
+	s.mu.Unlock()
+	if t != nil {
+		t.Stop()
+	}
+}
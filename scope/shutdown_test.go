@@ -0,0 +1,48 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRequestShutdownGracefulDrain(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast)
+	s.Go(func(ctx context.Context) error {
+		select {
+		case <-s.ShutdownRequested():
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	s.RequestShutdown(errors.New("stop"))
+	s.RequestShutdown(errors.New("ignored"))
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v, want nil", err)
+	}
+	if s.CancelReason() != ReasonNone {
+		t.Fatalf("CancelReason() = %v, want none", s.CancelReason())
+	}
+}
+
+func TestRequestShutdownTimeoutEscalates(t *testing.T) {
+	t.Parallel()
+	stop := errors.New("stop")
+	s := New(context.Background(), FailFast, WithShutdownTimeout(20*time.Millisecond))
+	s.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+	s.RequestShutdown(stop)
+	_ = s.Wait()
+	if s.CancelReason() != ReasonShutdown {
+		t.Fatalf("CancelReason() = %v, want shutdown", s.CancelReason())
+	}
+	cause := context.Cause(s.Context())
+	if !errors.Is(cause, ErrShutdownTimeout) || !errors.Is(cause, stop) {
+		t.Fatalf("cause = %v, want ErrShutdownTimeout wrapping stop", cause)
+	}
+}
@@ -0,0 +1,66 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// SignalError is the cancellation cause of a scope created with
+// NewFromSignals.
+type SignalError struct {
+	// Signal is the signal that was received.
+	Signal os.Signal
+	// Forced reports a signal received during the graceful shutdown, which
+	// forced the hard cancel.
+	Forced bool
+}
+
+func (e *SignalError) Error() string {
+	if e.Forced {
+		return fmt.Sprintf("scope: received signal %v during graceful shutdown", e.Signal)
+	}
+	return fmt.Sprintf("scope: received signal %v", e.Signal)
+}
+
+// NewFromSignals creates a root Scope bound to SIGINT and SIGTERM.
+//
+// The first signal calls RequestShutdown with a *SignalError, closing
+// ShutdownRequested so tasks can wind down gracefully. A second signal, or
+// the WithShutdownTimeout deadline, cancels the scope with Shutdown; its
+// cause records the signal. After that, signal handling is restored to the
+// default so a further signal terminates the process. Signal handling also
+// stops once Done is closed. Options such as WithTimeout and
+// WithDeadline apply as with New.
+func NewFromSignals(parent context.Context, policy Policy, optFns ...Option) *Scope {
+	s := New(parent, policy, optFns...)
+	sigs := make(chan os.Signal, 2)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		defer signal.Stop(sigs)
+		graceful := false
+		for {
+			select {
+			case sig := <-sigs:
+				select {
+				case <-s.Done():
+					// Both cases were ready; a joined scope ignores signals.
+					return
+				default:
+				}
+				if !graceful {
+					graceful = true
+					s.RequestShutdown(&SignalError{Signal: sig})
+					continue
+				}
+				s.Shutdown(&SignalError{Signal: sig, Forced: true})
+				return
+			case <-s.Done():
+				return
+			}
+		}
+	}()
+	return s
+}
@@ -0,0 +1,96 @@
+//go:build unix
+
+package scope
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// The signal tests are not parallel: a signal sent while no handler is
+// installed would terminate the test binary.
+
+func TestNewFromSignalsSecondSignalForces(t *testing.T) {
+	s := NewFromSignals(context.Background(), FailFast)
+	drained := make(chan struct{})
+	s.Go(func(ctx context.Context) error {
+		<-s.ShutdownRequested()
+		close(drained)
+		<-ctx.Done()
+		return nil
+	})
+	if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-drained:
+	case <-time.After(5 * time.Second):
+		t.Fatal("graceful shutdown not requested")
+	}
+	if err := s.Context().Err(); err != nil {
+		t.Fatalf("context canceled after first signal: %v", err)
+	}
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+	_ = s.Wait()
+	if s.CancelReason() != ReasonShutdown {
+		t.Fatalf("CancelReason() = %v, want shutdown", s.CancelReason())
+	}
+	var se *SignalError
+	if !errors.As(context.Cause(s.Context()), &se) || se.Signal != syscall.SIGTERM || !se.Forced {
+		t.Fatalf("cause = %v, want forced SIGTERM", context.Cause(s.Context()))
+	}
+}
+
+func TestNewFromSignalsTimeoutForces(t *testing.T) {
+	s := NewFromSignals(context.Background(), FailFast, WithShutdownTimeout(20*time.Millisecond))
+	s.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+	if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+		t.Fatal(err)
+	}
+	_ = s.Wait()
+	cause := context.Cause(s.Context())
+	var se *SignalError
+	if !errors.Is(cause, ErrShutdownTimeout) || !errors.As(cause, &se) || se.Signal != os.Interrupt {
+		t.Fatalf("cause = %v, want timeout after interrupt", cause)
+	}
+}
+
+func TestNewFromSignalsStopsOnJoin(t *testing.T) {
+	s := NewFromSignals(context.Background(), FailFast, WithTimeout(time.Second))
+	s.Go(func(ctx context.Context) error { return nil })
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v, want nil", err)
+	}
+	if _, ok := s.Context().Deadline(); !ok {
+		t.Fatal("WithTimeout not applied")
+	}
+
+	// Keep a handler installed so the signal cannot terminate the test
+	// binary once the scope has released its own.
+	caught := make(chan os.Signal, 1)
+	signal.Notify(caught, os.Interrupt)
+	defer signal.Stop(caught)
+	if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-caught:
+	case <-time.After(5 * time.Second):
+		t.Fatal("signal not delivered")
+	}
+	select {
+	case <-s.ShutdownRequested():
+		t.Fatal("joined scope reacted to a signal")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,157 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrFlightAbandoned is the cancellation cause of a call shared by Do once
+// every caller waiting for it has given up.
+var ErrFlightAbandoned = errors.New("scope: shared call abandoned by all callers")
+
+// flightGroup deduplicates Do calls across a scope tree.
+type flightGroup struct {
+	owner *Scope
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+type flight struct {
+	done    chan struct{}
+	cancel  context.CancelCauseFunc
+	waiters int
+	shared  bool
+	val     any
+	err     error
+}
+
+// treeRoot returns the scope created with New that s descends from.
+func (s *Scope) treeRoot() *Scope {
+	if s.root != nil {
+		return s.root
+	}
+	return s
+}
+
+// flightGroup returns the Do group of s's tree, creating it on first use.
+func (s *Scope) flightGroup() *flightGroup {
+	r := s.treeRoot()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.flights == nil {
+		r.flights = &flightGroup{owner: r, calls: make(map[string]*flight)}
+	}
+	return r.flights
+}
+
+// Do calls fn once for concurrent callers that pass the same key and whose
+// ctx carries scopes of the same tree: the scope created with New and every
+// scope derived from it with Child. Callers that arrive while the call is in
+// progress wait for it and receive the same result; shared reports whether
+// the result was given to more than one caller. All callers of a key must use
+// the same type T.
+//
+// The call runs on its own goroutine owned by the root of the tree, so it
+// counts toward that scope's Wait, is not subject to its Limiter, and is not
+// reported to its Observer or Policy; its error is only returned to the
+// callers. Its context is canceled with the root scope, or with
+// ErrFlightAbandoned once every waiting caller's ctx is done. A caller whose
+// ctx is done returns context.Cause(ctx) without waiting further. A panic in
+// fn is returned to every caller as a *PanicError.
+//
+// Without a scope in ctx, Do simply calls fn(ctx).
+func Do[T any](ctx context.Context, key string, fn func(ctx context.Context) (T, error)) (v T, err error, shared bool) {
+	s, ok := scopeFromContext(ctx)
+	if !ok {
+		v, err = fn(ctx)
+		return v, err, false
+	}
+	g := s.flightGroup()
+	g.mu.Lock()
+	f, ok := g.calls[key]
+	if ok {
+		f.waiters++
+		f.shared = true
+	} else {
+		f, err = g.start(key, func(ctx context.Context) (any, error) { return fn(ctx) })
+		if err != nil {
+			g.mu.Unlock()
+			return v, err, false
+		}
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+		g.leave(key, f)
+		return v, context.Cause(ctx), f.sharedNow(g)
+	}
+	if f.err != nil {
+		return v, f.err, f.shared
+	}
+	v, ok = f.val.(T)
+	if !ok && f.val != nil {
+		return v, fmt.Errorf("scope: Do(%q) result is %T, not %T", key, f.val, v), f.shared
+	}
+	return v, nil, f.shared
+}
+
+// start launches the call for key. It requires g.mu.
+func (g *flightGroup) start(key string, fn func(ctx context.Context) (any, error)) (*flight, error) {
+	o := g.owner
+	o.mu.Lock()
+	if err := o.reserveLocked(); err != nil {
+		o.mu.Unlock()
+		return nil, err
+	}
+	o.mu.Unlock()
+
+	ctx, cancel := context.WithCancelCause(o.ctx)
+	f := &flight{done: make(chan struct{}), cancel: cancel, waiters: 1}
+	g.calls[key] = f
+	go func() {
+		defer o.taskDone()
+		var val any
+		var err error
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					err = panicToError(r, key)
+				}
+			}()
+			val, err = fn(ctx)
+		}()
+		cancel(nil)
+		g.mu.Lock()
+		if g.calls[key] == f {
+			delete(g.calls, key)
+		}
+		f.val, f.err = val, err
+		g.mu.Unlock()
+		close(f.done)
+	}()
+	return f, nil
+}
+
+// leave removes a waiter that gave up and cancels the call when it was the
+// last one. A later Do for the same key starts a new call.
+func (g *flightGroup) leave(key string, f *flight) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	f.waiters--
+	if f.waiters == 0 {
+		if g.calls[key] == f {
+			delete(g.calls, key)
+		}
+		f.cancel(ErrFlightAbandoned)
+	}
+}
+
+func (f *flight) sharedNow(g *flightGroup) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return f.shared
+}
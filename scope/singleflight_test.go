@@ -0,0 +1,114 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDoSharesOneCallAcrossTree(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fetch := func(context.Context) (string, error) {
+		calls.Add(1)
+		<-release
+		return "profile", nil
+	}
+	var sharedCount atomic.Int32
+	run := func(sc *Scope) {
+		for i := 0; i < 15; i++ {
+			sc.Go(func(ctx context.Context) error {
+				v, err, shared := Do(ctx, "user:42", fetch)
+				if err != nil || v != "profile" {
+					t.Errorf("Do() = %q, %v", v, err)
+				}
+				if shared {
+					sharedCount.Add(1)
+				}
+				return nil
+			})
+		}
+	}
+	run(s)
+	run(s.Child(Supervisor))
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v", err)
+	}
+	if calls.Load() != 1 || sharedCount.Load() != 30 {
+		t.Fatalf("calls = %d, shared = %d; want 1 call shared by 30", calls.Load(), sharedCount.Load())
+	}
+}
+
+func TestDoCancelsOnlyWhenAllWaitersLeave(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast)
+	started := make(chan struct{})
+	causes := make(chan error, 1)
+	fn := func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		causes <- context.Cause(ctx)
+		return 0, ctx.Err()
+	}
+	ctx1, cancel1 := context.WithCancel(s.Context())
+	ctx2, cancel2 := context.WithCancel(s.Context())
+	errs := make(chan error, 2)
+	go func() { _, err, _ := Do(ctx1, "k", fn); errs <- err }()
+	<-started
+	go func() { _, err, _ := Do(ctx2, "k", fn); errs <- err }()
+	time.Sleep(10 * time.Millisecond)
+
+	cancel1()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("first caller = %v, want Canceled", err)
+	}
+	select {
+	case cause := <-causes:
+		t.Fatalf("call canceled with %v while a waiter remained", cause)
+	case <-time.After(10 * time.Millisecond):
+	}
+	cancel2()
+	<-errs
+	if cause := <-causes; !errors.Is(cause, ErrFlightAbandoned) {
+		t.Fatalf("call cause = %v, want ErrFlightAbandoned", cause)
+	}
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v", err)
+	}
+}
+
+func TestDoErrorsAndPanicsReachCallers(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	s := New(context.Background(), FailFast)
+	ctx := s.Context()
+	if _, err, _ := Do(ctx, "err", func(context.Context) (int, error) { return 0, boom }); !errors.Is(err, boom) {
+		t.Fatalf("Do() error = %v, want boom", err)
+	}
+	_, err, _ := Do(ctx, "panic", func(context.Context) (int, error) { panic("bad") })
+	var pe *PanicError
+	if !errors.As(err, &pe) || pe.Value() != "bad" {
+		t.Fatalf("Do() error = %v, want PanicError", err)
+	}
+	// Errors go to callers only, not to the scope's Policy.
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v, want nil", err)
+	}
+	if _, err, _ := Do(ctx, "late", func(context.Context) (int, error) { return 1, nil }); !errors.Is(err, ErrScopeClosed) {
+		t.Fatalf("Do() after Wait = %v, want ErrScopeClosed", err)
+	}
+}
+
+func TestDoWithoutScopeCallsDirectly(t *testing.T) {
+	t.Parallel()
+	v, err, shared := Do(context.Background(), "k", func(context.Context) (int, error) { return 7, nil })
+	if v != 7 || err != nil || shared {
+		t.Fatalf("Do() = %d, %v, %v", v, err, shared)
+	}
+}
@@ -0,0 +1,28 @@
+package scope
+
+import "context"
+
+// Spawner is the task-spawning subset of *Scope. Libraries that only start
+// work on behalf of a caller can accept a Spawner instead of *Scope, which
+// lets tests substitute the fakes in package scopetest.
+type Spawner interface {
+	Go(fn func(ctx context.Context) error)
+	TryGo(fn func(ctx context.Context) error) bool
+	SpawnChild(policy Policy, optFns ...Option) ChildSpawner
+	Context() context.Context
+}
+
+// ChildSpawner is a Spawner for a child scope, which its creator joins with
+// Wait. It is what Spawner.SpawnChild returns, so code written against
+// Spawner never needs a concrete *Scope.
+type ChildSpawner interface {
+	Spawner
+	Wait() error
+}
+
+// SpawnChild is Child returning the ChildSpawner view of the new scope.
+func (s *Scope) SpawnChild(policy Policy, optFns ...Option) ChildSpawner {
+	return s.Child(policy, optFns...)
+}
+
+var _ ChildSpawner = (*Scope)(nil)
@@ -0,0 +1,99 @@
+package scope
+
+import "context"
+
+// State describes where a Scope is in its lifecycle.
+type State int
+
+const (
+	// StateActive means the scope accepts new tasks.
+	StateActive State = iota
+	// StateCancelling means the scope has been canceled and its tasks have not
+	// all been joined yet.
+	StateCancelling
+	// StateWaiting means Wait has started and tasks are still running.
+	StateWaiting
+	// StateDone means Wait has joined every task; Err reports its result.
+	StateDone
+)
+
+// String returns the lower-case name of the state.
+func (st State) String() string {
+	switch st {
+	case StateActive:
+		return "active"
+	case StateCancelling:
+		return "cancelling"
+	case StateWaiting:
+		return "waiting"
+	case StateDone:
+		return "done"
+	default:
+		return "unknown"
+	}
+}
+
+// State reports the scope's current lifecycle state without blocking.
+func (s *Scope) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.markIdleLocked()
+	switch {
+	case s.done:
+		return StateDone
+	case s.canceled || s.ctx.Err() != nil:
+		return StateCancelling
+	case s.waiting:
+		return StateWaiting
+	default:
+		return StateActive
+	}
+}
+
+// Done returns a channel that is closed once every task of the scope has
+// finished and no new task can be admitted: after the scope was canceled,
+// by Cancel, a failing task, its parent or its deadline, or after Wait has
+// started. A canceled scope
+// closes Done without anyone calling Wait; Wait then only runs the Defer
+// hooks and returns the result. Done does not close while the scope can
+// still accept tasks, even if none is running.
+func (s *Scope) Done() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.idleCh == nil {
+		s.idleCh = make(chan struct{})
+		if s.idle {
+			close(s.idleCh)
+			return s.idleCh
+		}
+		s.markIdleLocked()
+		if !s.idle {
+			// A parent or deadline cancels the context without going
+			// through cancelWith.
+			s.stopIdle = context.AfterFunc(s.ctx, func() {
+				s.mu.Lock()
+				s.markIdleLocked()
+				s.mu.Unlock()
+			})
+		}
+	}
+	return s.idleCh
+}
+
+// Err returns nil until Done is closed. Afterwards it returns the error Wait
+// reports for the scope's tasks, and once Wait has joined the scope, Wait's
+// result, which also includes errors from Defer hooks. Unlike Wait, Err never
+// blocks and never re-panics.
+func (s *Scope) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.markIdleLocked()
+	switch {
+	case s.done:
+		return s.result
+	case s.idle:
+		return s.collectResult()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,182 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStateTransitions(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast)
+	if got := s.State(); got != StateActive {
+		t.Fatalf("want active, got %v", got)
+	}
+	release := make(chan struct{})
+	s.Go(func(_ context.Context) error {
+		<-release
+		return nil
+	})
+	waited := make(chan struct{})
+	go func() {
+		_ = s.Wait()
+		close(waited)
+	}()
+	deadline := time.Now().Add(time.Second)
+	for s.State() != StateWaiting {
+		if time.Now().After(deadline) {
+			t.Fatalf("scope never reached waiting, state=%v", s.State())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if s.Err() != nil {
+		t.Fatal("Err should be nil before Done")
+	}
+	s.Cancel(errors.New("stop"))
+	if got := s.State(); got != StateCancelling {
+		t.Fatalf("want cancelling, got %v", got)
+	}
+	close(release)
+	select {
+	case <-s.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done not closed after tasks joined")
+	}
+	<-waited
+	if got := s.State(); got != StateDone {
+		t.Fatalf("want done, got %v", got)
+	}
+	if err := s.Err(); err == nil || err.Error() != "stop" {
+		t.Fatalf("Err should report the Wait result, got %v", err)
+	}
+}
+
+func TestConcurrentWaitReturnsCachedResult(t *testing.T) {
+	t.Parallel()
+	obs := &countObserver{}
+	s := New(context.Background(), Supervisor, WithObserver(obs))
+	s.Go(func(_ context.Context) error {
+		time.Sleep(10 * time.Millisecond)
+		return errors.New("x")
+	})
+
+	const n = 8
+	results := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = s.Wait()
+		}()
+	}
+	wg.Wait()
+	for i := 1; i < n; i++ {
+		if results[i] != results[0] {
+			t.Fatalf("Wait results differ: %v vs %v", results[i], results[0])
+		}
+	}
+	if got := obs.joined.Load(); got != 1 {
+		t.Fatalf("expected a single ScopeJoined event, got %d", got)
+	}
+}
+
+func TestDoneClosesAfterCancelWithoutWait(t *testing.T) {
+	t.Parallel()
+	s := New(context.Background(), FailFast)
+	release := make(chan struct{})
+	s.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		<-release
+		return nil
+	})
+	idle := New(context.Background(), FailFast)
+	select {
+	case <-idle.Done():
+		t.Fatal("Done closed on a scope that still admits tasks")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	s.Cancel(nil)
+	select {
+	case <-s.Done():
+		t.Fatal("Done closed while a task was still running")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-s.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done not closed once the canceled scope's tasks finished")
+	}
+	if s.Err() != nil {
+		t.Fatal("Err should stay nil until Wait has joined the scope")
+	}
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() = %v", err)
+	}
+
+	idle.Cancel(nil)
+	select {
+	case <-idle.Done():
+	default:
+		t.Fatal("Done should close at once when a scope without tasks is canceled")
+	}
+	_ = idle.Wait()
+}
+
+func TestErrMatchesDoneBeforeWait(t *testing.T) {
+	t.Parallel()
+	boom := errors.New("boom")
+	s := New(context.Background(), FailFast)
+	s.Go(func(context.Context) error { return boom })
+	select {
+	case <-s.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done not closed after the failing task canceled the scope")
+	}
+	if err := s.Err(); !errors.Is(err, boom) {
+		t.Fatalf("Err() after Done = %v, want boom", err)
+	}
+	if err := s.Wait(); !errors.Is(err, boom) {
+		t.Fatalf("Wait() = %v, want boom", err)
+	}
+}
+
+func TestDoneClosesOnParentCancel(t *testing.T) {
+	t.Parallel()
+	parent, cancel := context.WithCancel(context.Background())
+	s := New(parent, Supervisor)
+	release := make(chan struct{})
+	s.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		<-release
+		return nil
+	})
+	idle := New(parent, Supervisor)
+	done, idleDone := s.Done(), idle.Done()
+	cancel()
+	select {
+	case <-idleDone:
+	case <-time.After(time.Second):
+		t.Fatal("Done not closed after the parent context was canceled")
+	}
+	if err := idle.TryGoErr(func(context.Context) error { return nil }); !errors.Is(err, ErrScopeCanceled) {
+		t.Fatalf("TryGoErr after parent cancel = %v, want ErrScopeCanceled", err)
+	}
+	select {
+	case <-done:
+		t.Fatal("Done closed while a task was still running")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Done not closed once the task finished after the parent cancel")
+	}
+	_ = s.Wait()
+	_ = idle.Wait()
+}